@@ -1,15 +1,19 @@
 package cashrampsdk
 
+//go:generate go run ./cmd/cashrampgen -schema schema/schema.graphql -config schema/codegen.json
+
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strings"
 
-	"github.com/rockets-hq/cashramp-sdk/mutations"
-	"github.com/rockets-hq/cashramp-sdk/queries"
+	"github.com/rockets-hq/cashramp-sdk/limits"
 	"github.com/rockets-hq/cashramp-sdk/types"
 )
 
@@ -19,29 +23,44 @@ type Client struct {
 	ApiUrl     string
 	secretKey  string
 	httpClient *http.Client
+
+	checkContracts     bool
+	persistedQueries   *persistedQueries
+	paymentMethodTypes *paymentMethodTypeCache
+	limits             *limits.Guard
 }
 
 type CashrampResponse struct {
 	Success bool `json:"success"`
 	Result  any
 	Error   string
+
+	// raw is Result as it was received, so SendRequestTyped can decode it
+	// without numbers passing through float64.
+	raw        json.RawMessage
+	statusCode int
 }
 
 type reqBody struct {
-	Query     string `json:"query"`
-	Variables any    `json:"variables"`
+	Query         string         `json:"query,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     any            `json:"variables"`
+	Extensions    *reqExtensions `json:"extensions,omitempty"`
 }
 
 type graphqlErrorResponse struct {
-	Message string `json:"message"`
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
 }
 
 type rawGraphQLResponse struct {
-	Data   map[string]any         `json:"data"`
-	Errors []graphqlErrorResponse `json:"errors"`
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []graphqlErrorResponse     `json:"errors"`
 }
 
-func InitialiseClient(environment, secretKey string) (*Client, error) {
+func InitialiseClient(environment, secretKey string, opts ...ClientOption) (*Client, error) {
 	apiUrl, err := validateEnv(environment)
 	if err != nil {
 		return nil, err
@@ -52,41 +71,51 @@ func InitialiseClient(environment, secretKey string) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
+	client := &Client{
 		ApiUrl:     apiUrl,
 		secretKey:  secret,
 		httpClient: http.DefaultClient,
-	}, nil
-}
-
-func (c *Client) SendRequest(name, query string, variables any) (*CashrampResponse, error) {
-	response := &CashrampResponse{}
-	requestBody := &reqBody{
-		Query:     query,
-		Variables: variables,
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
 
-	body, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, err
+	if client.checkContracts {
+		if err := CheckOperationContracts(); err != nil {
+			return nil, err
+		}
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.ApiUrl, bytes.NewBuffer(body))
+	return client, nil
+}
+
+// SendRequest runs query and returns the result of its root field name. A
+// query containing several operations needs WithOperationName to choose one.
+func (c *Client) SendRequest(name, query string, variables any, opts ...RequestOption) (*CashrampResponse, error) {
+	options := &requestOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(options)
+	}
+	operationName, err := resolveOperationName(query, options.operationName)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", c.secretKey))
-	resp, err := c.httpClient.Do(req)
+	response := &CashrampResponse{}
+	requestBody := &reqBody{
+		OperationName: operationName,
+		Variables:     variables,
+	}
+	resp, body, err := c.roundTrip(options.ctx, requestBody, query)
 	if err != nil {
 		return nil, err
 	}
 
+	response.statusCode = resp.StatusCode
 	switch resp.StatusCode {
 	case 200:
 		graphqlResponse := &rawGraphQLResponse{}
-		jsonErr := json.NewDecoder(resp.Body).Decode(graphqlResponse)
+		jsonErr := json.Unmarshal(body, graphqlResponse)
 		if jsonErr != nil {
 			response.Success = false
 			response.Error = jsonErr.Error()
@@ -99,7 +128,12 @@ func (c *Client) SendRequest(name, query string, variables any) (*CashrampRespon
 			return response, nil
 		} else {
 			response.Success = true
-			response.Result = graphqlResponse.Data[name]
+			response.raw = graphqlResponse.Data[name]
+			if response.raw != nil {
+				if err := json.Unmarshal(response.raw, &response.Result); err != nil {
+					return nil, err
+				}
+			}
 		}
 	default:
 		response.Success = false
@@ -110,144 +144,167 @@ func (c *Client) SendRequest(name, query string, variables any) (*CashrampRespon
 	return response, nil
 }
 
-func (c *Client) GetAvailableCountries() ([]types.Country, error) {
-	return SendRequestTyped[[]types.Country](c, "availableCountries", queries.AVAILABLE_COUNTRIES, nil)
+func (c *Client) GetMarketRate(countryCode types.CountryCode) (*types.MarketRate, error) {
+	if err := countryCode.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.doMarketRate(marketRateVariables{CountryCode: countryCode})
 }
 
-func (c *Client) GetMarketRate(countryCode string) (*types.MarketRate, error) {
-	variables := map[string]string{
-		"countryCode": countryCode,
+// Mutations
+
+func (c *Client) ConfirmTransaction(paymentRequest types.ConfirmTransactionInput, opts ...ConfirmOption) (bool, error) {
+	if err := paymentRequest.Validate(); err != nil {
+		return false, err
 	}
 
-	marketRate, err := SendRequestTyped[types.MarketRate](c, "marketRate", queries.MARKET_RATE, variables)
-	if err != nil {
-		return nil, err
+	options := &confirmOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := options.validate(paymentRequest.TransactionHash); err != nil {
+		return false, err
 	}
-	return &marketRate, nil
+
+	return c.doConfirmTransaction(paymentRequest)
 }
 
-func (c *Client) GetPaymentMethodTypes(countryId string) ([]types.PaymentMethodTypes, error) {
-	variables := map[string]string{
-		"country": countryId,
+func (c *Client) InitiateHostedPayment(payment types.InitiateHostedPaymentInput) (*types.HostedPaymentResponse, error) {
+	if err := payment.Validate(); err != nil {
+		return nil, err
 	}
 
-	paymentMethodTypes, err := SendRequestTyped[[]types.PaymentMethodTypes](c, "p2pPaymentMethodTypes", queries.PAYMENT_METHOD_TYPES, variables)
+	reservation, err := c.reserveHostedPaymentLimits(payment)
 	if err != nil {
 		return nil, err
 	}
-	return paymentMethodTypes, nil
-}
 
-func (c *Client) GetRampableAssets() ([]types.RampableAssets, error) {
-	rampableAssets, err := SendRequestTyped[[]types.RampableAssets](c, "rampableAssets", queries.RAMPABLE_ASSETS, nil)
+	initiatedPayment, err := c.doInitiateHostedPayment(payment)
 	if err != nil {
-		return nil, err
+		return nil, c.releaseLimits(reservation, err)
 	}
-
-	return rampableAssets, nil
+	return initiatedPayment, nil
 }
 
-func (c *Client) GetRampLimits() (*types.RampLimits, error) {
-	rampLimits, err := SendRequestTyped[types.RampLimits](c, "rampLimits", queries.RAMP_LIMITS, nil)
-	if err != nil {
-		return nil, err
+func (c *Client) CancelHostedPayment(payment types.CancelHostedPaymentInput) (bool, error) {
+	if err := payment.Validate(); err != nil {
+		return false, err
 	}
 
-	return &rampLimits, err
+	return c.doCancelHostedPayment(payment)
 }
 
-func (c *Client) GetPaymentRequest(reference string) (*types.PaymentRequest, error) {
-	variables := map[string]string{
-		"reference": reference,
-	}
-	paymentRequest, err := SendRequestTyped[types.PaymentRequest](c, "merchantPaymentRequest", queries.PAYMENT_REQUEST, variables)
-	if err != nil {
+func (c *Client) CreateCustomer(customer types.CreateCustomerInput) (*types.Customer, error) {
+	if err := customer.Validate(); err != nil {
 		return nil, err
 	}
 
-	return &paymentRequest, nil
+	return c.doCreateCustomer(customer)
 }
 
-func (c *Client) GetAccount() (*types.Account, error) {
-	account, err := SendRequestTyped[types.Account](c, "account", queries.ACCOUNT, nil)
-	if err != nil {
+func (c *Client) AddPaymentMethod(payment types.AddPaymentMethodInput) (*types.PaymentMethod, error) {
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validatePaymentMethodFields(payment); err != nil {
 		return nil, err
 	}
 
-	return &account, nil
+	return c.doAddPaymentMethod(payment)
 }
 
-// Mutations
+func (c *Client) WithdrawOnchain(payment types.WithdrawOnchainInput, opts ...WithdrawOption) (*types.Withdrawal, error) {
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
 
-func (c *Client) ConfirmTransaction(paymentRequest types.ConfirmTransactionInput) (bool, error) {
-	confirmedPayment, err := SendRequestTyped[bool](c, "confirmTransaction", mutations.CONFIRM_TRANSACTION, paymentRequest)
-	if err != nil {
-		return false, err
+	options := &withdrawOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := options.validate(payment.Address); err != nil {
+		return nil, err
 	}
-	return confirmedPayment, nil
-}
 
-func (c *Client) InitiateHostedPayment(payment types.InitiateHostedPaymentInput) (*types.HostedPaymentResponse, error) {
-	initiatedPayment, err := SendRequestTyped[types.HostedPaymentResponse](c, "initiateHostedPayment", mutations.INITIATE_HOSTED_PAYMENT, payment)
+	reservation, err := c.reserveWithdrawalLimits(payment)
 	if err != nil {
 		return nil, err
 	}
-	return &initiatedPayment, nil
-}
 
-func (c *Client) CancelHostedPayment(payment types.CancelHostedPaymentInput) (bool, error) {
-	initiatedPayment, err := SendRequestTyped[bool](c, "cancelHostedPayment", mutations.CANCEL_HOSTED_PAYMENT, payment)
+	initiatedPayment, err := c.doWithdrawOnchain(payment)
 	if err != nil {
-		return false, err
+		return nil, c.releaseLimits(reservation, err)
 	}
 	return initiatedPayment, nil
 }
 
-func (c *Client) CreateCustomer(customer types.CreateCustomerInput) (*types.Customer, error) {
-	createdCustomer, err := SendRequestTyped[types.Customer](c, "createCustomer", mutations.CREATE_CUSTOMER, customer)
+// post sends requestBody and returns the response with its body read.
+func (c *Client) post(ctx context.Context, requestBody *reqBody) (*http.Response, []byte, error) {
+	body, err := json.Marshal(requestBody)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &createdCustomer, nil
-}
 
-func (c *Client) AddPaymentMethod(payment types.AddPaymentMethodInput) (*types.AddPaymentMethodResponse, error) {
-	initiatedPayment, err := SendRequestTyped[types.AddPaymentMethodResponse](c, "addPaymentMethod", mutations.ADD_PAYMENT_METHOD, payment)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.ApiUrl, bytes.NewBuffer(body))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &initiatedPayment, nil
-}
 
-func (c *Client) WithdrawOnchain(payment types.WithdrawOnchainInput) (*types.WithdrawOnchainResponse, error) {
-	initiatedPayment, err := SendRequestTyped[types.WithdrawOnchainResponse](c, "withdrawOnchain", mutations.WITHDRAW_ONCHAIN, payment)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", c.secretKey))
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
 	}
-	return &initiatedPayment, nil
+	return resp, respBody, nil
+}
+
+// requestError is returned by SendRequestTyped when the API reports a
+// failure, either as a GraphQL error or an HTTP error status.
+type requestError struct {
+	statusCode int
+	message    string
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("request failed: %s", e.message)
 }
 
 // TODO: return error message from the server when there is one
-func SendRequestTyped[T any](client *Client, name, query string, variables any) (T, error) {
+func SendRequestTyped[T any](client *Client, name, query string, variables any, opts ...RequestOption) (T, error) {
 	var out T
-	resp, err := client.SendRequest(name, query, variables)
+	resp, err := client.SendRequest(name, query, variables, opts...)
 	if err != nil {
 		return out, err
 	}
 
 	if !resp.Success {
-		return out, fmt.Errorf("request failed: %s", resp.Error)
+		return out, &requestError{statusCode: resp.statusCode, message: resp.Error}
 	}
 
-	// Convert the generic result into typed output
-	raw, err := json.Marshal(resp.Result)
-	if err != nil {
-		return out, err
+	if resp.raw == nil {
+		return out, nil
 	}
-	err = json.Unmarshal(raw, &out)
+	err = json.Unmarshal(resp.raw, &out)
 	return out, err
 }
 
+// requireField returns a *types.ValidationError for field if value is empty
+// or whitespace, so lookups by ID fail before making a request.
+func requireField(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return &types.ValidationError{Errors: []types.FieldError{{Field: field, Message: "is required"}}}
+	}
+	return nil
+}
+
 func validateEnv(env string) (apiUrl string, err error) {
 	var environment string
 	if env == "" {
@@ -3,6 +3,7 @@ package cashrampsdk_test
 import (
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -65,6 +66,24 @@ func createMockGraphQLResponse(t *testing.T, operationName string, result any, g
 	return responseBytes
 }
 
+// mockGraphQLRouter answers each request with the result registered for the
+// first operation name found in the request body.
+func mockGraphQLRouter(t *testing.T, results map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		for operationName, result := range results {
+			if strings.Contains(string(body), operationName) {
+				w.Write(createMockGraphQLResponse(t, operationName, result))
+				return
+			}
+		}
+		t.Errorf("unexpected request: %s", body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+}
+
 func TestSendRequestSuccess(t *testing.T) {
 	operationName := "account"
 	mockResult := map[string]string{
@@ -125,6 +144,36 @@ func TestSendRequestHTTPError(t *testing.T) {
 	assert.Nil(t, resp.Result)
 }
 
+func TestSendRequestOperationName(t *testing.T) {
+	responseBytes := createMockGraphQLResponse(t, "account", map[string]string{"id": "1"})
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, `"operationName":"Account"`)
+	defer server.Close()
+
+	resp, err := dummyClient(t, server).SendRequest("account", queries.ACCOUNT, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+}
+
+func TestSendRequestMultipleOperations(t *testing.T) {
+	document := `
+		query Account { account { id } }
+		query Limits { rampLimits { dailyLimitUsd } }
+	`
+	responseBytes := createMockGraphQLResponse(t, "rampLimits", map[string]float64{"dailyLimitUsd": 500})
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, `"operationName":"Limits"`)
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	resp, err := client.SendRequest("rampLimits", document, nil, cashrampsdk.WithOperationName("Limits"))
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	_, err = client.SendRequest("rampLimits", document, nil)
+	assert.ErrorContains(t, err, "an operation name is required")
+	_, err = client.SendRequest("rampLimits", document, nil, cashrampsdk.WithOperationName("Missing"))
+	assert.ErrorContains(t, err, `no operation named "Missing"`)
+}
+
 func TestSendRequestTypedError(t *testing.T) {
 	operationName := "account"
 	errorMessage := "GraphQL error occurred"
@@ -159,16 +208,16 @@ func TestGetAvailableCountries(t *testing.T) {
 
 	assert.Equal(t, "1", countries[0].ID)
 	assert.Equal(t, "Nigeria", countries[0].Name)
-	assert.Equal(t, "NG", countries[0].Code)
+	assert.Equal(t, types.CountryCodeNG, countries[0].Code)
 
 	assert.Equal(t, "2", countries[1].ID)
 	assert.Equal(t, "United States", countries[1].Name)
-	assert.Equal(t, "US", countries[1].Code)
+	assert.Equal(t, types.CountryCode("US"), countries[1].Code)
 }
 
 func TestGetMarketRate(t *testing.T) {
 	operationName := "marketRate"
-	countryCode := "NG"
+	countryCode := types.CountryCodeNG
 	mockResult := map[string]float64{
 		"depositRate":    1520.0,
 		"withdrawalRate": 1515.0,
@@ -192,7 +241,11 @@ func TestGetPaymentMethodTypes(t *testing.T) {
 	operationName := "p2pPaymentMethodTypes"
 	countryID := "1"
 	mockResult := []map[string]any{
-		{"id": "1", "identifier": "BANK_TRANSFER", "label": "Bank Transfer"},
+		{"id": "1", "identifier": "BANK_TRANSFER", "label": "Bank Transfer", "fields": []map[string]any{
+			{"label": "Account Number", "identifier": "account_number", "required": true},
+			{"label": "Bank Name", "identifier": "bank_name", "required": true},
+			{"label": "Account Name", "identifier": "account_name", "required": false},
+		}},
 		{"id": "2", "identifier": "MOBILE_MONEY", "label": "Mobile Money"},
 	}
 
@@ -210,6 +263,11 @@ func TestGetPaymentMethodTypes(t *testing.T) {
 	assert.Equal(t, "1", paymentMethodTypes[0].ID)
 	assert.Equal(t, "BANK_TRANSFER", paymentMethodTypes[0].Identifier)
 	assert.Equal(t, "Bank Transfer", paymentMethodTypes[0].Label)
+	assert.Len(t, paymentMethodTypes[0].Fields, 3)
+	assert.Len(t, paymentMethodTypes[0].RequiredFields(), 2)
+	field, ok := paymentMethodTypes[0].Field("bank_name")
+	assert.True(t, ok)
+	assert.Equal(t, "Bank Name", field.Label)
 
 	assert.Equal(t, "2", paymentMethodTypes[1].ID)
 	assert.Equal(t, "MOBILE_MONEY", paymentMethodTypes[1].Identifier)
@@ -266,7 +324,7 @@ func TestGetPaymentRequest(t *testing.T) {
 	reference := "test_ref_1"
 	mockResult := map[string]any{
 		"id":          "1",
-		"paymentType": "ONCHAIN",
+		"paymentType": "deposit",
 		"hostedLink":  "https://payment-link.com",
 		"amount":      100.0,
 		"currency":    "USD",
@@ -285,7 +343,9 @@ func TestGetPaymentRequest(t *testing.T) {
 	assert.NotNil(t, paymentRequest)
 
 	assert.Equal(t, "1", paymentRequest.ID)
-	assert.Equal(t, "PENDING", paymentRequest.Status)
+	assert.Equal(t, types.PaymentStatus("PENDING"), paymentRequest.Status)
+	assert.False(t, paymentRequest.Status.IsKnown())
+	assert.Equal(t, types.PaymentTypeDeposit, paymentRequest.PaymentType)
 	assert.Equal(t, reference, paymentRequest.Reference)
 }
 
@@ -340,15 +400,16 @@ func TestInitiateHostedPayment(t *testing.T) {
 	}
 
 	responseBytes := createMockGraphQLResponse(t, operationName, mockResult)
-	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, operationName, `"reference":"ref123"`)
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, operationName, `"reference":"ref123"`, `"paymentType":"deposit"`)
 	defer server.Close()
 
 	client := dummyClient(t, server)
 
 	initiateHostedPaymentInput := types.InitiateHostedPaymentInput{
-		PaymentType: "ONCHAIN",
+		PaymentType: types.PaymentTypeDeposit,
 		Amount:      100.0,
-		Currency:    "USD",
+		Currency:    types.CurrencyCodeUSD,
+		CountryCode: types.CountryCodeNG,
 		Reference:   "ref123",
 		RedirectUrl: "https://redirect.com",
 		FirstName:   "John",
@@ -378,3 +439,115 @@ func TestCancelHostedPayment(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, cancelled)
 }
+
+func TestGetMarketRateInvalidCountryCode(t *testing.T) {
+	server := mockGraphQLServer(t, nil, http.StatusOK, true)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	_, err := client.GetMarketRate("XX")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ISO 3166-1")
+}
+
+func TestInitiateHostedPaymentValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid input should not reach the API")
+	}))
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	_, err := client.InitiateHostedPayment(types.InitiateHostedPaymentInput{
+		Amount:      -5,
+		CountryCode: "NG",
+		Email:       "not-an-email",
+	})
+
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	for _, field := range []string{"paymentType", "amount", "reference", "firstName", "lastName", "email"} {
+		_, ok := validationErr.Field(field)
+		assert.True(t, ok, "expected an error for %s", field)
+	}
+	_, ok := validationErr.Field("countryCode")
+	assert.False(t, ok)
+}
+
+func TestWithdrawOnchainValidation(t *testing.T) {
+	tests := []struct {
+		input types.WithdrawOnchainInput
+		field string
+	}{
+		{types.WithdrawOnchainInput{Amount: "10"}, "address"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "ten"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "0"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "NaN"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "Inf"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "0x1p4"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "1e3"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "-5"}, "amountUsd"},
+		{types.WithdrawOnchainInput{Address: "0x123", Amount: "1e400"}, "amountUsd"},
+	}
+
+	for _, tt := range tests {
+		var validationErr *types.ValidationError
+		assert.ErrorAs(t, tt.input.Validate(), &validationErr)
+		_, ok := validationErr.Field(tt.field)
+		assert.True(t, ok, "expected an error for %s", tt.field)
+	}
+
+	assert.NoError(t, types.WithdrawOnchainInput{Address: "0x123", Amount: "10.5"}.Validate())
+
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, types.InitiateHostedPaymentInput{Amount: math.NaN()}.Validate(), &validationErr)
+	amountErr, _ := validationErr.Field("amount")
+	assert.Equal(t, "must be a finite number", amountErr.Message)
+}
+
+func TestAddPaymentMethod(t *testing.T) {
+	operationName := "addPaymentMethod"
+	mockResult := map[string]any{
+		"id":    "pm_1",
+		"value": "0123456789",
+		"fields": []map[string]string{
+			{"identifier": "account_number", "value": "0123456789"},
+			{"identifier": "bank_name", "value": "Access Bank"},
+		},
+	}
+
+	responseBytes := createMockGraphQLResponse(t, operationName, mockResult)
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, operationName,
+		`"fields":[{"identifier":"account_number","value":"0123456789"},{"identifier":"bank_name","value":"Access Bank"}]`)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	input := types.NewAddPaymentMethodInput("cus_1", "1").
+		WithField("bank_name", "Wema Bank").
+		WithFields(map[string]string{"account_number": "0123456789", "bank_name": "Access Bank"})
+
+	paymentMethod, err := client.AddPaymentMethod(input)
+	assert.NoError(t, err)
+	assert.Equal(t, "pm_1", paymentMethod.ID)
+	bankName, ok := paymentMethod.FieldValue("bank_name")
+	assert.True(t, ok)
+	assert.Equal(t, "Access Bank", bankName)
+}
+
+func TestWithdrawOnchain(t *testing.T) {
+	operationName := "withdrawOnchain"
+	mockResult := map[string]any{"id": "wd_1", "status": "created"}
+
+	responseBytes := createMockGraphQLResponse(t, operationName, mockResult)
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, operationName, `"amountUsd":"25.50"`)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	withdrawal, err := client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "25.50"})
+	assert.NoError(t, err)
+	assert.Equal(t, "wd_1", withdrawal.ID)
+	assert.Equal(t, types.PaymentStatusCreated, withdrawal.Status)
+}
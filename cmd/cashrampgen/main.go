@@ -0,0 +1,45 @@
+// Command cashrampgen generates the SDK's types, queries, mutations and
+// Client methods from a schema snapshot. Run it from the module root with
+// go generate.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/rockets-hq/cashramp-sdk/internal/codegen"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "schema/schema.graphql", "schema snapshot, as SDL or an introspection result (.json)")
+	configPath := flag.String("config", "schema/codegen.json", "codegen config")
+	out := flag.String("out", ".", "module root to write the generated files under")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("cashrampgen: ")
+
+	schema, err := graphql.LoadSchema(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config, err := codegen.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := codegen.Generate(schema, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, contents := range files {
+		path := filepath.Join(*out, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, contents, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cashrampsdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/rockets-hq/cashramp-sdk/validation"
+)
+
+// operationBinding ties a built-in document to the type its variables are
+// encoded from. The bindings themselves are generated into client_gen.go.
+type operationBinding struct {
+	name     string
+	document string
+	input    any
+}
+
+// CheckOperationContracts verifies that every built-in query and mutation
+// declares exactly the variables its input type sends.
+func CheckOperationContracts() error {
+	var errs []error
+	for _, binding := range operationBindings {
+		if err := graphql.CheckVariables(binding.document, "", binding.input); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateOperations checks every built-in query and mutation against schema,
+// such as a snapshot loaded with graphql.LoadSchema, without contacting the
+// server.
+func ValidateOperations(schema *graphql.Schema) error {
+	var errs []error
+	for _, binding := range operationBindings {
+		if err := validation.Validate(schema, binding.document); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", binding.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
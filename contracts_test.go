@@ -0,0 +1,55 @@
+package cashrampsdk_test
+
+import (
+	"net/http"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOperationContracts(t *testing.T) {
+	assert.NoError(t, cashrampsdk.CheckOperationContracts())
+
+	_, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithContractCheck())
+	assert.NoError(t, err)
+}
+
+func TestValidateOperations(t *testing.T) {
+	schema, err := graphql.LoadSchema("schema/schema.graphql")
+	if assert.NoError(t, err) {
+		assert.NoError(t, cashrampsdk.ValidateOperations(schema))
+	}
+
+	drifted, err := graphql.ParseSchema(`type Query { account: Account! } type Account { id: ID! }`)
+	if assert.NoError(t, err) {
+		err = cashrampsdk.ValidateOperations(drifted)
+		assert.ErrorContains(t, err, "field accountBalance is not defined on Account")
+		assert.ErrorContains(t, err, "schema does not support mutation operations")
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	result := map[string]any{
+		"queryType": map[string]any{"name": "Query"},
+		"types": []any{
+			map[string]any{"kind": "OBJECT", "name": "Query", "fields": []any{
+				map[string]any{"name": "account", "args": []any{}, "type": map[string]any{
+					"kind": "NON_NULL", "ofType": map[string]any{"kind": "OBJECT", "name": "Account"},
+				}},
+			}},
+			map[string]any{"kind": "OBJECT", "name": "Account", "fields": []any{
+				map[string]any{"name": "id", "args": []any{}, "type": map[string]any{"kind": "SCALAR", "name": "ID"}},
+			}},
+		},
+	}
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "__schema", result), http.StatusOK, true, "IntrospectionQuery")
+	defer server.Close()
+
+	schema, err := dummyClient(t, server).Introspect()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Query", schema.QueryType)
+		assert.Equal(t, "Account!", schema.Type("Query").Field("account").Type.String())
+	}
+}
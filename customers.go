@@ -0,0 +1,78 @@
+package cashrampsdk
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// ErrCustomerNotFound is returned when no customer has the given ID or email
+// address.
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// GetCustomer fetches a customer by ID. It returns an error wrapping
+// ErrCustomerNotFound if there is no such customer.
+func (c *Client) GetCustomer(customerID string) (*types.Customer, error) {
+	if err := requireField("id", customerID); err != nil {
+		return nil, err
+	}
+
+	customer, err := c.doCustomer(customerVariables{ID: customerID})
+	if err != nil {
+		return nil, err
+	}
+	if customer.Id == "" {
+		return nil, fmt.Errorf("%w: %s", ErrCustomerNotFound, customerID)
+	}
+	return customer, nil
+}
+
+// ListCustomers iterates over every customer on the account.
+func (c *Client) ListCustomers(opts ...ListOption) iter.Seq2[types.Customer, error] {
+	return c.listCustomers("", newListOptions(opts))
+}
+
+// FindCustomerByEmail returns the customer registered with email, so callers
+// can reuse an existing profile rather than creating a duplicate. Emails are
+// compared case-insensitively, and results for other addresses are skipped in
+// case the API matches loosely. It returns an error wrapping
+// ErrCustomerNotFound if there is none.
+func (c *Client) FindCustomerByEmail(email string) (*types.Customer, error) {
+	email = strings.TrimSpace(email)
+	if err := requireField("email", email); err != nil {
+		return nil, err
+	}
+
+	for customer, err := range c.listCustomers(email, newListOptions(nil)) {
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(strings.TrimSpace(customer.Email), email) {
+			return &customer, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCustomerNotFound, email)
+}
+
+// UpdateCustomer changes the fields set on customer, leaving the rest as
+// they are, and returns the updated profile.
+func (c *Client) UpdateCustomer(customer types.UpdateCustomerInput) (*types.Customer, error) {
+	if err := customer.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.doUpdateCustomer(customer)
+}
+
+func (c *Client) listCustomers(email string, options *listOptions) iter.Seq2[types.Customer, error] {
+	return Paginate(func(cursor string) ([]types.Customer, types.PageInfo, error) {
+		page, err := c.doCustomers(customersVariables{Email: email, First: options.pageSize, After: cursor})
+		if err != nil {
+			return nil, types.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+}
@@ -0,0 +1,166 @@
+package cashrampsdk_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCustomer = map[string]any{
+	"id":        "cus_1",
+	"email":     "ada@example.com",
+	"firstName": "Ada",
+	"lastName":  "Obi",
+	"country":   map[string]string{"id": "NG", "name": "Nigeria", "code": "NG"},
+}
+
+func TestGetCustomer(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "customer", testCustomer), http.StatusOK, true, `"operationName":"Customer"`, `"id":"cus_1"`)
+	defer server.Close()
+
+	customer, err := dummyClient(t, server).GetCustomer("cus_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_1", customer.Id)
+	assert.Equal(t, "Ada", customer.FirstName)
+	assert.Equal(t, types.CountryCodeNG, customer.Country.Code)
+}
+
+func TestGetCustomerNotFound(t *testing.T) {
+	server := mockGraphQLServer(t, []byte(`{"data":{"customer":null}}`), http.StatusOK, true)
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	_, err := client.GetCustomer("cus_404")
+	assert.ErrorIs(t, err, cashrampsdk.ErrCustomerNotFound)
+
+	_, err = client.GetCustomer(" ")
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestFindCustomerByEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		// The search matches loosely, so other customers come back first.
+		nodes := []any{map[string]any{"id": "cus_9", "email": "ada.lovelace@example.com"}}
+		if body.Variables["email"] == "ADA@example.com" {
+			nodes = append(nodes, testCustomer)
+		}
+		assert.Equal(t, float64(cashrampsdk.DefaultPageSize), body.Variables["first"])
+		w.Write(createMockGraphQLResponse(t, "customers", map[string]any{
+			"nodes":    nodes,
+			"pageInfo": map[string]any{"hasNextPage": false},
+		}))
+	}))
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	customer, err := client.FindCustomerByEmail(" ADA@example.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_1", customer.Id)
+
+	_, err = client.FindCustomerByEmail("ada@example.co")
+	assert.ErrorIs(t, err, cashrampsdk.ErrCustomerNotFound)
+	assert.ErrorContains(t, err, "ada@example.co")
+}
+
+func TestListCustomers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.NotContains(t, body.Variables, "email")
+
+		page := map[string]any{
+			"nodes":    []any{testCustomer},
+			"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"},
+		}
+		if body.Variables["after"] == "c1" {
+			page = map[string]any{
+				"nodes":    []any{map[string]any{"id": "cus_2", "email": "grace@example.com"}},
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c2"},
+			}
+		}
+		w.Write(createMockGraphQLResponse(t, "customers", page))
+	}))
+	defer server.Close()
+
+	var ids []string
+	for customer, err := range dummyClient(t, server).ListCustomers(cashrampsdk.WithPageSize(1)) {
+		assert.NoError(t, err)
+		ids = append(ids, customer.Id)
+	}
+	assert.Equal(t, []string{"cus_1", "cus_2"}, ids)
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	updated := map[string]any{"id": "cus_1", "email": "ada@example.com", "firstName": "Adaeze", "lastName": "Obi"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			OperationName string         `json:"operationName"`
+			Variables     map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "UpdateCustomer", body.OperationName)
+		assert.Equal(t, map[string]any{"customer": "cus_1", "firstName": "Adaeze"}, body.Variables)
+		w.Write(createMockGraphQLResponse(t, "updateCustomer", updated))
+	}))
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	customer, err := client.UpdateCustomer(types.UpdateCustomerInput{CustomerID: "cus_1", FirstName: "Adaeze"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Adaeze", customer.FirstName)
+
+	_, err = client.UpdateCustomer(types.UpdateCustomerInput{CustomerID: "cus_1", Email: "not-an-email"})
+	var validationErr *types.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		_, ok := validationErr.Field("email")
+		assert.True(t, ok)
+	}
+
+	_, err = client.UpdateCustomer(types.UpdateCustomerInput{CustomerID: "cus_1"})
+	assert.ErrorContains(t, err, "nothing to update")
+}
+
+func TestGetCustomerAs(t *testing.T) {
+	type customerWithCountryName struct {
+		types.Customer
+		Country struct {
+			Name string `json:"name"`
+		} `json:"country"`
+	}
+
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "customer", testCustomer), http.StatusOK, true, `country {\n      name\n    }`)
+	defer server.Close()
+
+	customer, err := cashrampsdk.GetCustomerAs[customerWithCountryName](dummyClient(t, server), "cus_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ada@example.com", customer.Email)
+	assert.Equal(t, "Nigeria", customer.Country.Name)
+}
+
+func TestGetCustomerAsMissingID(t *testing.T) {
+	requests := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	_, err := cashrampsdk.GetCustomerAs[types.Customer](dummyClient(t, server), " ")
+	var validationErr *types.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "id", validationErr.Errors[0].Field)
+	}
+	assert.Zero(t, requests.Load())
+}
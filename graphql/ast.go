@@ -0,0 +1,193 @@
+package graphql
+
+import "fmt"
+
+type OperationType string
+
+const (
+	OperationQuery        OperationType = "query"
+	OperationMutation     OperationType = "mutation"
+	OperationSubscription OperationType = "subscription"
+)
+
+// Document is a parsed executable GraphQL document.
+type Document struct {
+	Operations []*Operation
+	Fragments  []*Fragment
+}
+
+type Operation struct {
+	Type         OperationType
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet SelectionSet
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  SelectionSet
+}
+
+type VariableDefinition struct {
+	Name         string
+	Type         *Type
+	DefaultValue *Value
+}
+
+// Required reports whether the variable must be supplied: it is non-null and
+// has no default value.
+func (v *VariableDefinition) Required() bool {
+	return v.Type.NonNull && v.DefaultValue == nil
+}
+
+// Type is a GraphQL type reference. Elem is set for list types, Name for
+// named types.
+type Type struct {
+	Name    string
+	Elem    *Type
+	NonNull bool
+}
+
+func (t *Type) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// NamedType returns the innermost named type, unwrapping lists.
+func (t *Type) NamedType() string {
+	for t.Elem != nil {
+		t = t.Elem
+	}
+	return t.Name
+}
+
+type SelectionSet []Selection
+
+// Selection is one of *Field, *FragmentSpread or *InlineFragment.
+type Selection interface {
+	selection()
+}
+
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet SelectionSet
+}
+
+// ResponseKey is the key the field's result appears under in the response.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  SelectionSet
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+type Argument struct {
+	Name  string
+	Value *Value
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value is an input value literal. Raw holds the variable name, scalar text
+// or enum name; List and Fields hold the members of composite values.
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []*Value
+	Fields []*ObjectField
+}
+
+type ObjectField struct {
+	Name  string
+	Value *Value
+}
+
+// Operation returns the operation called name. An empty name selects the
+// document's only operation.
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, fmt.Errorf("graphql: document has %d operations, an operation name is required", len(d.Operations))
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("graphql: no operation named %q", name)
+}
+
+func (d *Document) Fragment(name string) *Fragment {
+	for _, fragment := range d.Fragments {
+		if fragment.Name == name {
+			return fragment
+		}
+	}
+	return nil
+}
+
+func (o *Operation) Variable(name string) *VariableDefinition {
+	for _, variable := range o.Variables {
+		if variable.Name == name {
+			return variable
+		}
+	}
+	return nil
+}
+
+// RootFields returns the top level fields selected by the operation.
+func (o *Operation) RootFields() []*Field {
+	var fields []*Field
+	for _, selection := range o.SelectionSet {
+		if field, ok := selection.(*Field); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
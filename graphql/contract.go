@@ -0,0 +1,145 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ContractError reports a mismatch between the variables an operation
+// declares and the JSON keys its bound input type sends.
+type ContractError struct {
+	Operation string
+	// Missing lists declared variables the input never supplies.
+	Missing []string
+	// Undeclared lists input keys the operation does not declare.
+	Undeclared []string
+}
+
+func (e *ContractError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("variables not supplied by input: $%s", strings.Join(e.Missing, ", $")))
+	}
+	if len(e.Undeclared) > 0 {
+		problems = append(problems, fmt.Sprintf("input keys not declared as variables: %s", strings.Join(e.Undeclared, ", ")))
+	}
+	return fmt.Sprintf("graphql: operation %s: %s", e.Operation, strings.Join(problems, "; "))
+}
+
+// CheckVariables verifies that input supplies exactly the variables declared
+// by the named operation in document. input may be nil, a struct (its JSON
+// field names are used) or a map with string keys (its keys are used).
+func CheckVariables(document, operationName string, input any) error {
+	doc, err := Parse(document)
+	if err != nil {
+		return err
+	}
+	op, err := doc.Operation(operationName)
+	if err != nil {
+		return err
+	}
+
+	keys, err := InputKeys(input)
+	if err != nil {
+		return err
+	}
+
+	contractErr := &ContractError{Operation: operationLabel(op)}
+	supplied := map[string]bool{}
+	for _, key := range keys {
+		supplied[key] = true
+		if op.Variable(key) == nil {
+			contractErr.Undeclared = append(contractErr.Undeclared, key)
+		}
+	}
+	for _, variable := range op.Variables {
+		if !supplied[variable.Name] {
+			contractErr.Missing = append(contractErr.Missing, variable.Name)
+		}
+	}
+
+	if len(contractErr.Missing) == 0 && len(contractErr.Undeclared) == 0 {
+		return nil
+	}
+	return contractErr
+}
+
+// InputKeys returns the top level JSON keys input encodes to, sorted.
+func InputKeys(input any) ([]string, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(input)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return inputTypeKeys(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Map {
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("graphql: input map keys must be strings, got %s", v.Type().Key())
+		}
+		keys := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		return keys, nil
+	}
+	return inputTypeKeys(v.Type())
+}
+
+func inputTypeKeys(t reflect.Type) ([]string, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("graphql: input must be a struct or map, got %s", t)
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				embeddedKeys, err := inputTypeKeys(embedded)
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, embeddedKeys...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func operationLabel(op *Operation) string {
+	if op.Name != "" {
+		return op.Name
+	}
+	if fields := op.RootFields(); len(fields) > 0 {
+		return fields[0].Name
+	}
+	return string(op.Type)
+}
@@ -0,0 +1,248 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOperation(t *testing.T) {
+	doc, err := graphql.Parse(`
+		# fetch a payment request
+		query PaymentRequest($reference: String!, $first: Int = 10, $tags: [String!]) {
+			request: merchantPaymentRequest(reference: $reference, filter: {status: COMPLETED, ids: ["a", "b"]}) {
+				id
+				... on PaymentRequest { status }
+				...Amounts @include(if: true)
+			}
+		}
+
+		fragment Amounts on PaymentRequest {
+			amount
+			currency
+		}
+	`)
+	assert.NoError(t, err)
+
+	op, err := doc.Operation("")
+	assert.NoError(t, err)
+	assert.Equal(t, graphql.OperationQuery, op.Type)
+	assert.Equal(t, "PaymentRequest", op.Name)
+
+	assert.Len(t, op.Variables, 3)
+	assert.True(t, op.Variable("reference").Required())
+	assert.False(t, op.Variable("first").Required())
+	assert.Equal(t, "[String!]", op.Variable("tags").Type.String())
+	assert.Equal(t, "String", op.Variable("tags").Type.NamedType())
+
+	root := op.RootFields()[0]
+	assert.Equal(t, "request", root.ResponseKey())
+	assert.Equal(t, "merchantPaymentRequest", root.Name)
+	assert.Equal(t, graphql.ValueVariable, root.Arguments[0].Value.Kind)
+	assert.Equal(t, graphql.ValueObject, root.Arguments[1].Value.Kind)
+	assert.Len(t, root.SelectionSet, 3)
+	assert.IsType(t, &graphql.InlineFragment{}, root.SelectionSet[1])
+	assert.IsType(t, &graphql.FragmentSpread{}, root.SelectionSet[2])
+
+	assert.NotNil(t, doc.Fragment("Amounts"))
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	_, err := graphql.Parse("query {\n  account {\n    id\n")
+
+	var syntaxErr *graphql.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, 4, syntaxErr.Line)
+
+	_, err = graphql.Parse("mutation ($a: String = $b) { x }")
+	assert.Error(t, err)
+}
+
+func TestCheckVariables(t *testing.T) {
+	type input struct {
+		Address string `json:"address"`
+		Amount  string `json:"amount,omitempty"`
+		Ignored string `json:"-"`
+	}
+
+	err := graphql.CheckVariables(`mutation ($address: String!, $amountUsd: Decimal!) { withdrawOnchain(address: $address, amountUsd: $amountUsd) { id } }`, "", input{})
+
+	var contractErr *graphql.ContractError
+	assert.ErrorAs(t, err, &contractErr)
+	assert.Equal(t, "withdrawOnchain", contractErr.Operation)
+	assert.Equal(t, []string{"amountUsd"}, contractErr.Missing)
+	assert.Equal(t, []string{"amount"}, contractErr.Undeclared)
+
+	assert.NoError(t, graphql.CheckVariables(`query ($country: ID!) { p2pPaymentMethodTypes(country: $country) { id } }`, "", map[string]string{"country": "1"}))
+	assert.NoError(t, graphql.CheckVariables(`query { account { id } }`, "", nil))
+}
+
+func TestPrintRoundTrip(t *testing.T) {
+	source := `query Search($term: String = "a \"quoted\"\nterm", $first: Int!) @cached {
+  results: search(term: $term, first: $first, filter: {tags: ["x", "y"], active: true}) {
+    id
+    ... on Customer {
+      email
+    }
+    ...Names
+  }
+}
+
+fragment Names on Customer {
+  firstName
+  lastName
+}`
+
+	doc, err := graphql.Parse(source)
+	assert.NoError(t, err)
+	assert.Equal(t, source, doc.String())
+
+	reparsed, err := graphql.Parse(doc.String())
+	assert.NoError(t, err)
+	assert.Equal(t, doc, reparsed)
+}
+
+func TestSelectionFor(t *testing.T) {
+	type currency struct {
+		IsoCode string `json:"isoCode"`
+	}
+	type base struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	type country struct {
+		base
+		Name     string      `json:"displayName" graphql:"name"`
+		Currency *currency   `json:"currency,omitempty"`
+		Regions  []base      `json:"regions"`
+		Status   statusValue `json:"status"`
+		Ignored  string      `json:"-"`
+		internal string
+	}
+
+	selections, err := graphql.SelectionFor(reflect.TypeFor[[]country]())
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  id
+  name
+  displayName: name
+  currency {
+    isoCode
+  }
+  regions {
+    id
+    name
+  }
+  status
+}`, selections.String())
+
+	_, err = graphql.SelectionFor(reflect.TypeFor[string]())
+	assert.Error(t, err)
+}
+
+type statusValue struct{ value string }
+
+func (s *statusValue) UnmarshalJSON(data []byte) error {
+	s.value = string(data)
+	return nil
+}
+
+func TestParseSchema(t *testing.T) {
+	schema, err := graphql.ParseSchema(`
+		"Amounts with arbitrary precision"
+		scalar Decimal
+
+		directive @auth(scope: String) on FIELD_DEFINITION | OBJECT
+
+		enum Status {
+			created
+			old @deprecated(reason: "use created")
+		}
+
+		type Query {
+			"Look up a payment"
+			payment(reference: String!, limit: Int = 10): Payment @auth(scope: "read")
+			payments: [Payment!]!
+		}
+
+		type Payment implements Node & Timestamped {
+			id: ID!
+			amount: Decimal!
+			status: Status
+		}
+
+		input Filter {
+			status: Status = created
+		}
+
+		union Result = | Payment | Query
+	`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Query", schema.QueryType)
+	assert.Empty(t, schema.MutationType)
+	root, err := schema.RootType(graphql.OperationQuery)
+	assert.NoError(t, err)
+	_, err = schema.RootType(graphql.OperationMutation)
+	assert.Error(t, err)
+
+	payment := root.Field("payment")
+	assert.Equal(t, "Look up a payment", payment.Description)
+	assert.Equal(t, "Payment", payment.Type.String())
+	assert.Equal(t, "10", payment.Argument("limit").DefaultValue.Raw)
+	assert.Equal(t, "[Payment!]!", root.Field("payments").Type.String())
+
+	assert.Equal(t, "Amounts with arbitrary precision", schema.Type("Decimal").Description)
+	assert.Equal(t, []string{"Node", "Timestamped"}, schema.Type("Payment").Interfaces)
+	assert.Equal(t, []string{"Payment", "Query"}, schema.Type("Result").PossibleTypes)
+	assert.Equal(t, "use created", *schema.Type("Status").EnumValue("old").DeprecationReason)
+	assert.Nil(t, schema.Type("Status").EnumValue("created").DeprecationReason)
+	assert.Equal(t, "created", schema.Type("Filter").InputField("status").DefaultValue.Raw)
+	assert.True(t, schema.Type("String").IsLeaf())
+	assert.True(t, schema.Type("Filter").IsInput())
+
+	_, err = graphql.ParseSchema("type A { id: ID }\ntype A { id: ID }")
+	assert.Error(t, err)
+	_, err = graphql.ParseSchema("type A { id }")
+	var syntaxErr *graphql.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+}
+
+func TestSchemaFromIntrospection(t *testing.T) {
+	schema, err := graphql.SchemaFromIntrospection([]byte(`{"data": {"__schema": {
+		"queryType": {"name": "Query"},
+		"mutationType": null,
+		"types": [
+			{"kind": "OBJECT", "name": "Query", "fields": [
+				{"name": "countries", "args": [
+					{"name": "first", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "20"}
+				], "type": {"kind": "NON_NULL", "ofType": {"kind": "LIST", "ofType": {"kind": "NON_NULL", "ofType": {"kind": "OBJECT", "name": "Country"}}}}},
+				{"name": "legacy", "args": [], "type": {"kind": "SCALAR", "name": "String"}, "isDeprecated": true, "deprecationReason": null}
+			]},
+			{"kind": "OBJECT", "name": "Country", "fields": [
+				{"name": "code", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "String"}}}
+			]},
+			{"kind": "OBJECT", "name": "__Schema", "fields": []},
+			{"kind": "SCALAR", "name": "String"},
+			{"kind": "SCALAR", "name": "Int"}
+		]
+	}}}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Query", schema.QueryType)
+	assert.Nil(t, schema.Type("__Schema"))
+	countries := schema.Type("Query").Field("countries")
+	assert.Equal(t, "[Country!]!", countries.Type.String())
+	assert.Equal(t, "20", countries.Argument("first").DefaultValue.Raw)
+	assert.Equal(t, "No longer supported", *schema.Type("Query").Field("legacy").DeprecationReason)
+	assert.NotNil(t, schema.Type("Boolean"))
+
+	_, err = graphql.SchemaFromIntrospection([]byte(`{"data": {}}`))
+	assert.Error(t, err)
+}
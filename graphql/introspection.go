@@ -0,0 +1,286 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IntrospectionQuery fetches everything SchemaFromIntrospection reads.
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType { kind name }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type introspectionResult struct {
+	Data   *introspectionResult `json:"data"`
+	Schema *introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	QueryType        *introspectionNamed `json:"queryType"`
+	MutationType     *introspectionNamed `json:"mutationType"`
+	SubscriptionType *introspectionNamed `json:"subscriptionType"`
+	Types            []introspectionType `json:"types"`
+}
+
+type introspectionNamed struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind          TypeKind                 `json:"kind"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Fields        []introspectionField     `json:"fields"`
+	InputFields   []introspectionInput     `json:"inputFields"`
+	Interfaces    []introspectionNamed     `json:"interfaces"`
+	EnumValues    []introspectionEnumValue `json:"enumValues"`
+	PossibleTypes []introspectionNamed     `json:"possibleTypes"`
+}
+
+type introspectionField struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	Args              []introspectionInput `json:"args"`
+	Type              *introspectionRef    `json:"type"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type introspectionInput struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Type         *introspectionRef `json:"type"`
+	DefaultValue *string           `json:"defaultValue"`
+}
+
+type introspectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type introspectionRef struct {
+	Kind   string            `json:"kind"`
+	Name   string            `json:"name"`
+	OfType *introspectionRef `json:"ofType"`
+}
+
+// SchemaFromIntrospection loads a schema from the JSON result of the standard
+// introspection query, either the full response ({"data": {"__schema": ...}})
+// or just its data. Introspection types (those named __*) are left out.
+func SchemaFromIntrospection(data []byte) (*Schema, error) {
+	var result introspectionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("graphql: decoding introspection result: %w", err)
+	}
+	if result.Data != nil {
+		result = *result.Data
+	}
+	if result.Schema == nil {
+		return nil, errors.New("graphql: introspection result has no __schema")
+	}
+
+	schema := &Schema{
+		QueryType:        rootName(result.Schema.QueryType),
+		MutationType:     rootName(result.Schema.MutationType),
+		SubscriptionType: rootName(result.Schema.SubscriptionType),
+	}
+	for _, t := range result.Schema.Types {
+		if strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+		def, err := t.definition()
+		if err != nil {
+			return nil, err
+		}
+		schema.Types = append(schema.Types, def)
+	}
+	schema.addBuiltins()
+	return schema, nil
+}
+
+// LoadSchema reads a schema snapshot from an introspection result if path
+// ends in .json, and from SDL otherwise.
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(path) == ".json" {
+		return SchemaFromIntrospection(data)
+	}
+	return ParseSchema(string(data))
+}
+
+func rootName(named *introspectionNamed) string {
+	if named == nil {
+		return ""
+	}
+	return named.Name
+}
+
+func (t introspectionType) definition() (*TypeDefinition, error) {
+	def := &TypeDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description}
+	for _, field := range t.Fields {
+		typ, err := field.Type.typ()
+		if err != nil {
+			return nil, fmt.Errorf("graphql: %s.%s: %w", t.Name, field.Name, err)
+		}
+		arguments, err := inputValueDefinitions(field.Args)
+		if err != nil {
+			return nil, fmt.Errorf("graphql: %s.%s: %w", t.Name, field.Name, err)
+		}
+		fieldDef := &FieldDefinition{Name: field.Name, Description: field.Description, Arguments: arguments, Type: typ}
+		if field.IsDeprecated {
+			fieldDef.DeprecationReason = deprecated(field.DeprecationReason)
+		}
+		def.Fields = append(def.Fields, fieldDef)
+	}
+
+	inputFields, err := inputValueDefinitions(t.InputFields)
+	if err != nil {
+		return nil, fmt.Errorf("graphql: %s: %w", t.Name, err)
+	}
+	def.InputFields = inputFields
+
+	for _, value := range t.EnumValues {
+		valueDef := &EnumValueDefinition{Name: value.Name, Description: value.Description}
+		if value.IsDeprecated {
+			valueDef.DeprecationReason = deprecated(value.DeprecationReason)
+		}
+		def.EnumValues = append(def.EnumValues, valueDef)
+	}
+	for _, named := range t.Interfaces {
+		def.Interfaces = append(def.Interfaces, named.Name)
+	}
+	for _, named := range t.PossibleTypes {
+		def.PossibleTypes = append(def.PossibleTypes, named.Name)
+	}
+	return def, nil
+}
+
+func inputValueDefinitions(inputs []introspectionInput) ([]*InputValueDefinition, error) {
+	var defs []*InputValueDefinition
+	for _, input := range inputs {
+		typ, err := input.Type.typ()
+		if err != nil {
+			return nil, err
+		}
+		def := &InputValueDefinition{Name: input.Name, Description: input.Description, Type: typ}
+		if input.DefaultValue != nil {
+			p, err := newParser(*input.DefaultValue)
+			if err != nil {
+				return nil, err
+			}
+			if def.DefaultValue, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func deprecated(reason *string) *string {
+	if reason == nil {
+		noReason := "No longer supported"
+		return &noReason
+	}
+	return reason
+}
+
+func (r *introspectionRef) typ() (*Type, error) {
+	if r == nil {
+		return nil, errors.New("missing type reference")
+	}
+	switch r.Kind {
+	case "NON_NULL":
+		inner, err := r.OfType.typ()
+		if err != nil {
+			return nil, err
+		}
+		inner.NonNull = true
+		return inner, nil
+	case "LIST":
+		elem, err := r.OfType.typ()
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Elem: elem}, nil
+	default:
+		if r.Name == "" {
+			return nil, fmt.Errorf("unnamed %s type reference", r.Kind)
+		}
+		return &Type{Name: r.Name}, nil
+	}
+}
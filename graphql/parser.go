@@ -0,0 +1,429 @@
+package graphql
+
+// Parse parses an executable GraphQL document: operations and fragments.
+func Parse(document string) (*Document, error) {
+	p, err := newParser(document)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			selectionSet, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: OperationQuery, SelectionSet: selectionSet})
+		case p.peekName("query", "mutation", "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekName("fragment"):
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.Fragments = append(doc.Fragments, fragment)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func newParser(src string) (*parser, error) {
+	p := &parser{lex: &lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) peekName(names ...string) bool {
+	if p.tok.kind != tokenName {
+		return false
+	}
+	for _, name := range names {
+		if p.tok.value == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.lex.errorf(p.tok.pos, "unexpected end of document")
+	}
+	return p.lex.errorf(p.tok.pos, "unexpected %s %q", p.tok.kind, p.tok.value)
+}
+
+// skip consumes punctuator if it is next and reports whether it did.
+func (p *parser) skip(punctuator string) (bool, error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		if p.tok.kind == tokenEOF {
+			return p.lex.errorf(p.tok.pos, "expected %q, found end of document", punctuator)
+		}
+		return p.lex.errorf(p.tok.pos, "expected %q, found %q", punctuator, p.tok.value)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.peekName(keyword) {
+		return p.lex.errorf(p.tok.pos, "expected %q, found %q", keyword, p.tok.value)
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.lex.errorf(p.tok.pos, "expected name, found %s %q", p.tok.kind, p.tok.value)
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: OperationType(p.tok.value)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.tok.kind == tokenName {
+		if op.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if op.Variables, err = p.variableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+	if op.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if op.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	if err := p.expectKeyword("fragment"); err != nil {
+		return nil, err
+	}
+
+	fragment := &Fragment{}
+	var err error
+	if fragment.Name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if fragment.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if fragment.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if fragment.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return fragment, nil
+}
+
+func (p *parser) variableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var variables []*VariableDefinition
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		variable := &VariableDefinition{}
+		var err error
+		if variable.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if variable.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if variable.DefaultValue, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+		variables = append(variables, variable)
+	}
+	return variables, p.advance()
+}
+
+func (p *parser) typeRef() (*Type, error) {
+	t := &Type{}
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		if t.Elem, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		if t.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return nil, err
+	}
+	t.NonNull = nonNull
+	return t, nil
+}
+
+func (p *parser) selectionSet() (SelectionSet, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections SelectionSet
+	for !p.peek("}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.unexpected()
+		}
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, p.lex.errorf(p.tok.pos, "selection set must not be empty")
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (Selection, error) {
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.fragmentSelection()
+	}
+	return p.field()
+}
+
+func (p *parser) fragmentSelection() (Selection, error) {
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := &FragmentSpread{}
+		var err error
+		if spread.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if spread.Directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		return spread, nil
+	}
+
+	inline := &InlineFragment{}
+	var err error
+	if p.peekName("on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if inline.TypeCondition, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if inline.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) field() (*Field, error) {
+	field := &Field{}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+
+	if field.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+
+	var arguments []*Argument
+	for !p.peek(")") {
+		argument := &Argument{}
+		var err error
+		if argument.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if argument.Value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments, p.advance()
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		directive := &Directive{}
+		var err error
+		if directive.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if directive.Arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive)
+	}
+	return directives, nil
+}
+
+// value parses an input value. Variables are rejected when constant is set,
+// as in default values.
+func (p *parser) value(constant bool) (*Value, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		return &Value{Kind: ValueInt, Raw: tok.value}, p.advance()
+	case tokenFloat:
+		return &Value{Kind: ValueFloat, Raw: tok.value}, p.advance()
+	case tokenString, tokenBlockString:
+		return &Value{Kind: ValueString, Raw: tok.value}, p.advance()
+	case tokenName:
+		switch tok.value {
+		case "true", "false":
+			return &Value{Kind: ValueBoolean, Raw: tok.value}, p.advance()
+		case "null":
+			return &Value{Kind: ValueNull, Raw: tok.value}, p.advance()
+		}
+		return &Value{Kind: ValueEnum, Raw: tok.value}, p.advance()
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Kind: ValueVariable, Raw: name}, nil
+	case p.peek("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := &Value{Kind: ValueList}
+		for !p.peek("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list.List = append(list.List, item)
+		}
+		return list, p.advance()
+	case p.peek("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		object := &Value{Kind: ValueObject}
+		for !p.peek("}") {
+			field := &ObjectField{}
+			var err error
+			if field.Name, err = p.name(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if field.Value, err = p.value(constant); err != nil {
+				return nil, err
+			}
+			object.Fields = append(object.Fields, field)
+		}
+		return object, p.advance()
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,165 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// String prints the document with two space indentation.
+func (d *Document) String() string {
+	var parts []string
+	for _, op := range d.Operations {
+		parts = append(parts, op.String())
+	}
+	for _, fragment := range d.Fragments {
+		parts = append(parts, fragment.String())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (o *Operation) String() string {
+	var b strings.Builder
+	b.WriteString(string(o.Type))
+	if o.Name != "" {
+		b.WriteString(" " + o.Name)
+	}
+	if len(o.Variables) > 0 {
+		vars := make([]string, len(o.Variables))
+		for i, variable := range o.Variables {
+			vars[i] = "$" + variable.Name + ": " + variable.Type.String()
+			if variable.DefaultValue != nil {
+				vars[i] += " = " + variable.DefaultValue.String()
+			}
+		}
+		b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+	writeDirectives(&b, o.Directives)
+	b.WriteString(" ")
+	writeSelectionSet(&b, o.SelectionSet, 0)
+	return b.String()
+}
+
+func (f *Fragment) String() string {
+	var b strings.Builder
+	b.WriteString("fragment " + f.Name + " on " + f.TypeCondition)
+	writeDirectives(&b, f.Directives)
+	b.WriteString(" ")
+	writeSelectionSet(&b, f.SelectionSet, 0)
+	return b.String()
+}
+
+func (s SelectionSet) String() string {
+	var b strings.Builder
+	writeSelectionSet(&b, s, 0)
+	return b.String()
+}
+
+func writeSelectionSet(b *strings.Builder, selections SelectionSet, depth int) {
+	indent := strings.Repeat("  ", depth+1)
+	b.WriteString("{\n")
+	for _, selection := range selections {
+		b.WriteString(indent)
+		switch s := selection.(type) {
+		case *Field:
+			if s.Alias != "" {
+				b.WriteString(s.Alias + ": ")
+			}
+			b.WriteString(s.Name)
+			writeArguments(b, s.Arguments)
+			writeDirectives(b, s.Directives)
+			if len(s.SelectionSet) > 0 {
+				b.WriteString(" ")
+				writeSelectionSet(b, s.SelectionSet, depth+1)
+			}
+		case *FragmentSpread:
+			b.WriteString("..." + s.Name)
+			writeDirectives(b, s.Directives)
+		case *InlineFragment:
+			b.WriteString("...")
+			if s.TypeCondition != "" {
+				b.WriteString(" on " + s.TypeCondition)
+			}
+			writeDirectives(b, s.Directives)
+			b.WriteString(" ")
+			writeSelectionSet(b, s.SelectionSet, depth+1)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat("  ", depth) + "}")
+}
+
+func writeArguments(b *strings.Builder, arguments []*Argument) {
+	if len(arguments) == 0 {
+		return
+	}
+	args := make([]string, len(arguments))
+	for i, argument := range arguments {
+		args[i] = argument.Name + ": " + argument.Value.String()
+	}
+	b.WriteString("(" + strings.Join(args, ", ") + ")")
+}
+
+func writeDirectives(b *strings.Builder, directives []*Directive) {
+	for _, directive := range directives {
+		b.WriteString(" @" + directive.Name)
+		writeArguments(b, directive.Arguments)
+	}
+}
+
+func (v *Value) String() string {
+	switch v.Kind {
+	case ValueVariable:
+		return "$" + v.Raw
+	case ValueString:
+		return quote(v.Raw)
+	case ValueList:
+		items := make([]string, len(v.List))
+		for i, item := range v.List {
+			items[i] = item.String()
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ValueObject:
+		fields := make([]string, len(v.Fields))
+		for i, field := range v.Fields {
+			fields[i] = field.Name + ": " + field.Value.String()
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return v.Raw
+	}
+}
+
+// quote renders s as a GraphQL string literal. Unlike strconv.Quote it only
+// uses escapes the GraphQL grammar accepts.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				b.WriteString(`\u00`)
+				b.WriteString(strconv.FormatInt(int64(r)>>4, 16))
+				b.WriteString(strconv.FormatInt(int64(r)&0xf, 16))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package graphql
+
+import "fmt"
+
+type TypeKind string
+
+const (
+	KindScalar      TypeKind = "SCALAR"
+	KindObject      TypeKind = "OBJECT"
+	KindInterface   TypeKind = "INTERFACE"
+	KindUnion       TypeKind = "UNION"
+	KindEnum        TypeKind = "ENUM"
+	KindInputObject TypeKind = "INPUT_OBJECT"
+)
+
+// Schema is a GraphQL type system, loaded from SDL with ParseSchema or from an
+// introspection result with SchemaFromIntrospection.
+type Schema struct {
+	QueryType        string
+	MutationType     string
+	SubscriptionType string
+	// Types holds every named type, in definition order.
+	Types []*TypeDefinition
+}
+
+type TypeDefinition struct {
+	Kind        TypeKind
+	Name        string
+	Description string
+	// Fields is set for objects and interfaces.
+	Fields []*FieldDefinition
+	// InputFields is set for input objects.
+	InputFields []*InputValueDefinition
+	// EnumValues is set for enums.
+	EnumValues []*EnumValueDefinition
+	// Interfaces lists the interfaces an object implements.
+	Interfaces []string
+	// PossibleTypes lists the members of a union.
+	PossibleTypes []string
+}
+
+type FieldDefinition struct {
+	Name              string
+	Description       string
+	Arguments         []*InputValueDefinition
+	Type              *Type
+	DeprecationReason *string
+}
+
+type InputValueDefinition struct {
+	Name         string
+	Description  string
+	Type         *Type
+	DefaultValue *Value
+}
+
+type EnumValueDefinition struct {
+	Name              string
+	Description       string
+	DeprecationReason *string
+}
+
+var builtinScalars = []string{"ID", "String", "Int", "Float", "Boolean"}
+
+// Type returns the named type, or nil.
+func (s *Schema) Type(name string) *TypeDefinition {
+	for _, t := range s.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// RootType returns the root type for operations of kind op.
+func (s *Schema) RootType(op OperationType) (*TypeDefinition, error) {
+	var name string
+	switch op {
+	case OperationQuery:
+		name = s.QueryType
+	case OperationMutation:
+		name = s.MutationType
+	case OperationSubscription:
+		name = s.SubscriptionType
+	}
+	if name == "" {
+		return nil, fmt.Errorf("graphql: schema does not support %s operations", op)
+	}
+	root := s.Type(name)
+	if root == nil {
+		return nil, fmt.Errorf("graphql: schema root type %s is not defined", name)
+	}
+	return root, nil
+}
+
+func (t *TypeDefinition) Field(name string) *FieldDefinition {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (t *TypeDefinition) InputField(name string) *InputValueDefinition {
+	for _, field := range t.InputFields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (t *TypeDefinition) EnumValue(name string) *EnumValueDefinition {
+	for _, value := range t.EnumValues {
+		if value.Name == name {
+			return value
+		}
+	}
+	return nil
+}
+
+func (f *FieldDefinition) Argument(name string) *InputValueDefinition {
+	for _, argument := range f.Arguments {
+		if argument.Name == name {
+			return argument
+		}
+	}
+	return nil
+}
+
+// IsLeaf reports whether values of the type are scalars or enums, which take
+// no selection set.
+func (t *TypeDefinition) IsLeaf() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum
+}
+
+// IsInput reports whether the type may be used for arguments and variables.
+func (t *TypeDefinition) IsInput() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum || t.Kind == KindInputObject
+}
+
+// addBuiltins defines the built-in scalars when the source did not.
+func (s *Schema) addBuiltins() {
+	for _, name := range builtinScalars {
+		if s.Type(name) == nil {
+			s.Types = append(s.Types, &TypeDefinition{Kind: KindScalar, Name: name})
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package graphql
+
+// ParseSchema parses a schema in the GraphQL schema definition language.
+// Directive definitions are accepted and ignored; type extensions are not
+// supported.
+func ParseSchema(sdl string) (*Schema, error) {
+	p, err := newParser(sdl)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{}
+	explicitRoots := false
+	for p.tok.kind != tokenEOF {
+		description, err := p.description()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.peekName("schema"):
+			explicitRoots = true
+			if err := p.schemaDefinition(schema); err != nil {
+				return nil, err
+			}
+		case p.peekName("directive"):
+			if err := p.skipDirectiveDefinition(); err != nil {
+				return nil, err
+			}
+		case p.peekName("scalar", "type", "interface", "union", "enum", "input"):
+			def, err := p.typeDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if schema.Type(def.Name) != nil {
+				return nil, p.lex.errorf(p.tok.pos, "type %s is defined more than once", def.Name)
+			}
+			def.Description = description
+			schema.Types = append(schema.Types, def)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if !explicitRoots {
+		for name, root := range map[string]*string{"Query": &schema.QueryType, "Mutation": &schema.MutationType, "Subscription": &schema.SubscriptionType} {
+			if schema.Type(name) != nil {
+				*root = name
+			}
+		}
+	}
+	schema.addBuiltins()
+	return schema, nil
+}
+
+func (p *parser) description() (string, error) {
+	if p.tok.kind != tokenString && p.tok.kind != tokenBlockString {
+		return "", nil
+	}
+	description := p.tok.value
+	return description, p.advance()
+}
+
+func (p *parser) schemaDefinition(schema *Schema) error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if _, err := p.directives(); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.peek("}") {
+		operation, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		typeName, err := p.name()
+		if err != nil {
+			return err
+		}
+		switch OperationType(operation) {
+		case OperationQuery:
+			schema.QueryType = typeName
+		case OperationMutation:
+			schema.MutationType = typeName
+		case OperationSubscription:
+			schema.SubscriptionType = typeName
+		default:
+			return p.lex.errorf(p.tok.pos, "unknown operation type %q", operation)
+		}
+	}
+	return p.advance()
+}
+
+func (p *parser) skipDirectiveDefinition() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+	if _, err := p.name(); err != nil {
+		return err
+	}
+	if p.peek("(") {
+		if _, err := p.inputValueDefinitions("(", ")"); err != nil {
+			return err
+		}
+	}
+	if p.peekName("repeatable") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return err
+	}
+	if _, err := p.skip("|"); err != nil {
+		return err
+	}
+	for {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if ok, err := p.skip("|"); err != nil || !ok {
+			return err
+		}
+	}
+}
+
+func (p *parser) typeDefinition() (*TypeDefinition, error) {
+	keyword := p.tok.value
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	def := &TypeDefinition{Name: name}
+
+	switch keyword {
+	case "scalar":
+		def.Kind = KindScalar
+		_, err = p.directives()
+	case "type", "interface":
+		def.Kind = KindObject
+		if keyword == "interface" {
+			def.Kind = KindInterface
+		}
+		if def.Interfaces, err = p.implementsInterfaces(); err != nil {
+			return nil, err
+		}
+		if _, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if p.peek("{") {
+			def.Fields, err = p.fieldDefinitions()
+		}
+	case "union":
+		def.Kind = KindUnion
+		if _, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if ok, skipErr := p.skip("="); skipErr != nil {
+			return nil, skipErr
+		} else if ok {
+			def.PossibleTypes, err = p.unionMembers()
+		}
+	case "enum":
+		def.Kind = KindEnum
+		if _, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if p.peek("{") {
+			def.EnumValues, err = p.enumValueDefinitions()
+		}
+	case "input":
+		def.Kind = KindInputObject
+		if _, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if p.peek("{") {
+			def.InputFields, err = p.inputValueDefinitions("{", "}")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+func (p *parser) implementsInterfaces() ([]string, error) {
+	if !p.peekName("implements") {
+		return nil, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if _, err := p.skip("&"); err != nil {
+		return nil, err
+	}
+
+	var interfaces []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		interfaces = append(interfaces, name)
+		if ok, err := p.skip("&"); err != nil {
+			return nil, err
+		} else if !ok {
+			return interfaces, nil
+		}
+	}
+}
+
+func (p *parser) unionMembers() ([]string, error) {
+	if _, err := p.skip("|"); err != nil {
+		return nil, err
+	}
+	var members []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, name)
+		if ok, err := p.skip("|"); err != nil {
+			return nil, err
+		} else if !ok {
+			return members, nil
+		}
+	}
+}
+
+func (p *parser) fieldDefinitions() ([]*FieldDefinition, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var fields []*FieldDefinition
+	for !p.peek("}") {
+		field := &FieldDefinition{}
+		var err error
+		if field.Description, err = p.description(); err != nil {
+			return nil, err
+		}
+		if field.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if p.peek("(") {
+			if field.Arguments, err = p.inputValueDefinitions("(", ")"); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if field.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		directives, err := p.directives()
+		if err != nil {
+			return nil, err
+		}
+		field.DeprecationReason = deprecationReason(directives)
+		fields = append(fields, field)
+	}
+	return fields, p.advance()
+}
+
+func (p *parser) inputValueDefinitions(open, close string) ([]*InputValueDefinition, error) {
+	if err := p.expect(open); err != nil {
+		return nil, err
+	}
+
+	var values []*InputValueDefinition
+	for !p.peek(close) {
+		value := &InputValueDefinition{}
+		var err error
+		if value.Description, err = p.description(); err != nil {
+			return nil, err
+		}
+		if value.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if value.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if value.DefaultValue, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, p.advance()
+}
+
+func (p *parser) enumValueDefinitions() ([]*EnumValueDefinition, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var values []*EnumValueDefinition
+	for !p.peek("}") {
+		value := &EnumValueDefinition{}
+		var err error
+		if value.Description, err = p.description(); err != nil {
+			return nil, err
+		}
+		if value.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		directives, err := p.directives()
+		if err != nil {
+			return nil, err
+		}
+		value.DeprecationReason = deprecationReason(directives)
+		values = append(values, value)
+	}
+	return values, p.advance()
+}
+
+// deprecationReason returns the reason given by an @deprecated directive, or
+// nil if there is none.
+func deprecationReason(directives []*Directive) *string {
+	for _, directive := range directives {
+		if directive.Name != "deprecated" {
+			continue
+		}
+		reason := "No longer supported"
+		for _, argument := range directive.Arguments {
+			if argument.Name == "reason" && argument.Value.Kind == ValueString {
+				reason = argument.Value.Raw
+			}
+		}
+		return &reason
+	}
+	return nil
+}
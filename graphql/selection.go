@@ -0,0 +1,139 @@
+package graphql
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// SelectionFor derives a selection set from the JSON fields of struct type t,
+// so a response decodes into t without unused or missing fields. Nested
+// structs, including slice and pointer elements, become sub-selections;
+// everything else, and any type with its own JSON or text decoding, is
+// selected as a leaf. Embedded structs are flattened as encoding/json does.
+//
+// A `graphql:"name"` tag selects the schema field name and aliases it to the
+// JSON key when the two differ.
+func SelectionFor(t reflect.Type) (SelectionSet, error) {
+	return selectionFor(t, map[reflect.Type]bool{})
+}
+
+func selectionFor(t reflect.Type, visiting map[reflect.Type]bool) (SelectionSet, error) {
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("graphql: cannot derive a selection from %s", t)
+	}
+	if visiting[t] {
+		return nil, fmt.Errorf("graphql: %s refers to itself, selections must be finite", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var selections SelectionSet
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		key, _, _ := strings.Cut(tag, ",")
+
+		if structField.Anonymous && key == "" && elemType(structField.Type).Kind() == reflect.Struct {
+			embedded, err := selectionFor(structField.Type, visiting)
+			if err != nil {
+				return nil, err
+			}
+			selections = mergeSelections(selections, embedded, false)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = structField.Name
+		}
+
+		field := &Field{Name: key}
+		if name := structField.Tag.Get("graphql"); name != "" && name != key {
+			field.Alias, field.Name = key, name
+		}
+		if isComposite(structField.Type) {
+			sub, err := selectionFor(structField.Type, visiting)
+			if err != nil {
+				return nil, err
+			}
+			field.SelectionSet = sub
+		}
+		selections = mergeSelections(selections, SelectionSet{field}, true)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("graphql: %s has no JSON fields to select", t)
+	}
+	return selections, nil
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isComposite(t reflect.Type) bool {
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	ptr := reflect.PointerTo(t)
+	return !ptr.Implements(jsonUnmarshalerType) && !ptr.Implements(textUnmarshalerType)
+}
+
+// mergeSelections appends extra to base. Fields already in base under the same
+// response key are replaced when override is set and kept otherwise, so that
+// as in encoding/json a struct's own fields shadow those of embedded structs.
+func mergeSelections(base, extra SelectionSet, override bool) SelectionSet {
+	for _, selection := range extra {
+		field, ok := selection.(*Field)
+		replaced := false
+		if ok {
+			for i, existing := range base {
+				if existingField, isField := existing.(*Field); isField && existingField.ResponseKey() == field.ResponseKey() {
+					if override {
+						base[i] = field
+					}
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			base = append(base, selection)
+		}
+	}
+	return base
+}
+
+// ReplaceSelection replaces the selection set of the root field with the given
+// response key in every operation of doc that selects it.
+func ReplaceSelection(doc *Document, rootField string, selections SelectionSet) error {
+	found := false
+	for _, op := range doc.Operations {
+		for _, field := range op.RootFields() {
+			if field.ResponseKey() == rootField {
+				field.SelectionSet = selections
+				found = true
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("graphql: document does not select %q", rootField)
+	}
+	return nil
+}
@@ -0,0 +1,504 @@
+// Package codegen generates the SDK's types, documents and client methods
+// from a GraphQL schema snapshot. It backs cmd/cashrampgen.
+package codegen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/format"
+	gotypes "go/types"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+)
+
+// Generated files, relative to the module root.
+const (
+	TypesFile         = "types/types_gen.go"
+	QueriesFile       = "queries/queries_gen.go"
+	MutationsFile     = "mutations/mutations_gen.go"
+	SubscriptionsFile = "subscriptions/subscriptions_gen.go"
+	ClientFile        = "client_gen.go"
+)
+
+const header = "// Code generated by cashrampgen. DO NOT EDIT.\n\n"
+
+// Generate returns the gofmt'd contents of each generated file, keyed by its
+// path relative to the module root.
+func Generate(schema *graphql.Schema, config *Config) (map[string][]byte, error) {
+	g := &generator{schema: schema, config: config}
+	operations, err := g.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := map[string]func([]*operation) (string, error){
+		TypesFile:   g.typesFile,
+		QueriesFile: func(ops []*operation) (string, error) { return g.documentsFile("queries", graphql.OperationQuery, ops) },
+		MutationsFile: func(ops []*operation) (string, error) {
+			return g.documentsFile("mutations", graphql.OperationMutation, ops)
+		},
+		SubscriptionsFile: func(ops []*operation) (string, error) {
+			return g.documentsFile("subscriptions", graphql.OperationSubscription, ops)
+		},
+		ClientFile: g.clientFile,
+	}
+	files := map[string][]byte{}
+	for name, source := range sources {
+		src, err := source(operations)
+		if err != nil {
+			return nil, err
+		}
+		formatted, err := format.Source([]byte(header + src))
+		if err != nil {
+			return nil, fmt.Errorf("codegen: formatting %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+type generator struct {
+	schema *graphql.Schema
+	config *Config
+}
+
+// operation is a configured root field resolved against the schema.
+type operation struct {
+	*OperationConfig
+	typ      graphql.OperationType
+	def      *graphql.FieldDefinition
+	document string
+	args     []structField
+}
+
+type structField struct {
+	name     string
+	typ      string
+	jsonName string
+	// omitEmpty is set for nullable arguments and input fields, so an unset
+	// value is sent as null rather than as its zero value.
+	omitEmpty bool
+}
+
+func (g *generator) operations() ([]*operation, error) {
+	var operations []*operation
+	for i := range g.config.Operations {
+		op := &operation{OperationConfig: &g.config.Operations[i]}
+		for _, typ := range []graphql.OperationType{graphql.OperationQuery, graphql.OperationMutation, graphql.OperationSubscription} {
+			root, err := g.schema.RootType(typ)
+			if err != nil {
+				continue
+			}
+			if op.def = root.Field(op.Field); op.def != nil {
+				op.typ = typ
+				break
+			}
+		}
+		if op.def == nil {
+			return nil, fmt.Errorf("codegen: schema has no query, mutation or subscription field %q", op.Field)
+		}
+		if op.typ == graphql.OperationSubscription && (op.Method != "" || op.Input != "") {
+			return nil, fmt.Errorf("codegen: subscription %s cannot have a method or input type, its methods are written by hand", op.Field)
+		}
+
+		for _, arg := range op.def.Arguments {
+			field, err := g.structField(arg.Name, arg.Type, op.Args[arg.Name])
+			if err != nil {
+				return nil, fmt.Errorf("codegen: %s(%s): %w", op.Field, arg.Name, err)
+			}
+			field.omitEmpty = !arg.Type.NonNull
+			op.args = append(op.args, field)
+		}
+		document, err := g.document(op)
+		if err != nil {
+			return nil, err
+		}
+		op.document = document
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// document renders the operation selecting every field of the result type.
+func (g *generator) document(op *operation) (string, error) {
+	field := &graphql.Field{Name: op.Field}
+	var variables []*graphql.VariableDefinition
+	for _, arg := range op.def.Arguments {
+		field.Arguments = append(field.Arguments, &graphql.Argument{
+			Name:  arg.Name,
+			Value: &graphql.Value{Kind: graphql.ValueVariable, Raw: arg.Name},
+		})
+		variables = append(variables, &graphql.VariableDefinition{Name: arg.Name, Type: arg.Type})
+	}
+
+	result := g.schema.Type(op.def.Type.NamedType())
+	if result == nil {
+		return "", fmt.Errorf("codegen: %s returns undefined type %s", op.Field, op.def.Type.NamedType())
+	}
+	if !result.IsLeaf() {
+		selections, err := g.selection(result, map[string]bool{})
+		if err != nil {
+			return "", fmt.Errorf("codegen: %s: %w", op.Field, err)
+		}
+		field.SelectionSet = selections
+	}
+
+	document := (&graphql.Operation{Type: op.typ, Name: operationName(op), Variables: variables, SelectionSet: graphql.SelectionSet{field}}).String()
+	if strings.Contains(document, "`") {
+		return "", fmt.Errorf("codegen: %s document contains a backquote", op.Field)
+	}
+	return document, nil
+}
+
+func (g *generator) selection(def *graphql.TypeDefinition, visiting map[string]bool) (graphql.SelectionSet, error) {
+	if visiting[def.Name] {
+		return nil, fmt.Errorf("type %s refers to itself", def.Name)
+	}
+	visiting[def.Name] = true
+	defer delete(visiting, def.Name)
+
+	config := g.config.typeConfig(def.Name)
+	var selections graphql.SelectionSet
+	for _, fieldDef := range def.Fields {
+		if config != nil && config.omits(fieldDef.Name) {
+			continue
+		}
+		field := &graphql.Field{Name: fieldDef.Name}
+		fieldType := g.schema.Type(fieldDef.Type.NamedType())
+		if fieldType == nil {
+			return nil, fmt.Errorf("%s.%s has undefined type %s", def.Name, fieldDef.Name, fieldDef.Type.NamedType())
+		}
+		if !fieldType.IsLeaf() {
+			sub, err := g.selection(fieldType, visiting)
+			if err != nil {
+				return nil, err
+			}
+			field.SelectionSet = sub
+		}
+		selections = append(selections, field)
+	}
+	return selections, nil
+}
+
+func (g *generator) structField(name string, typ *graphql.Type, override FieldConfig) (structField, error) {
+	field := structField{name: override.Name, typ: override.Type, jsonName: name}
+	if field.name == "" {
+		field.name = exportedName(name)
+	}
+	if field.typ == "" {
+		var err error
+		if field.typ, err = g.goType(typ); err != nil {
+			return field, err
+		}
+	}
+	return field, nil
+}
+
+// goType returns the Go type for a schema type, as named in the types
+// package.
+func (g *generator) goType(typ *graphql.Type) (string, error) {
+	if typ.Elem != nil {
+		elem, err := g.goType(typ.Elem)
+		return "[]" + elem, err
+	}
+
+	switch typ.Name {
+	case "ID", "String":
+		return "string", nil
+	case "Int":
+		return "int", nil
+	case "Float":
+		return "float64", nil
+	case "Boolean":
+		return "bool", nil
+	}
+	if mapped, ok := g.config.Scalars[typ.Name]; ok {
+		return mapped, nil
+	}
+
+	def := g.schema.Type(typ.Name)
+	if def == nil {
+		return "", fmt.Errorf("undefined type %s", typ.Name)
+	}
+	switch def.Kind {
+	case graphql.KindEnum:
+		return "string", nil
+	case graphql.KindObject, graphql.KindInputObject:
+		if config := g.config.typeConfig(typ.Name); config != nil {
+			return config.Go, nil
+		}
+		return "", fmt.Errorf("type %s has no Go name, add it to types", typ.Name)
+	case graphql.KindScalar:
+		return "", fmt.Errorf("scalar %s has no Go type, add it to scalars", typ.Name)
+	default:
+		return "", fmt.Errorf("%s types such as %s are not supported", strings.ToLower(string(def.Kind)), typ.Name)
+	}
+}
+
+// resultType returns the Go type an operation decodes into and whether the
+// method returns a pointer to it.
+func (g *generator) resultType(op *operation) (string, bool, error) {
+	typ, err := g.goType(op.def.Type)
+	if err != nil {
+		return "", false, fmt.Errorf("codegen: %s: %w", op.Field, err)
+	}
+	result := g.schema.Type(op.def.Type.NamedType())
+	return typ, op.def.Type.Elem == nil && !result.IsLeaf(), nil
+}
+
+func (g *generator) typesFile(operations []*operation) (string, error) {
+	var b bytes.Buffer
+	imports := map[string]bool{}
+	addImports := func(fields []structField) {
+		for _, field := range fields {
+			if pkg, _, ok := strings.Cut(strings.TrimLeft(field.typ, "[]*"), "."); ok {
+				imports[pkg] = true
+			}
+		}
+	}
+
+	for _, config := range g.config.Types {
+		if config.External {
+			continue
+		}
+		def := g.schema.Type(config.GraphQL)
+		if def == nil {
+			return "", fmt.Errorf("codegen: schema has no type %s", config.GraphQL)
+		}
+
+		var fields []structField
+		add := func(name string, typ *graphql.Type, input bool) error {
+			if config.omits(name) {
+				return nil
+			}
+			field, err := g.structField(name, typ, config.Fields[name])
+			if err != nil {
+				return fmt.Errorf("codegen: %s.%s: %w", def.Name, name, err)
+			}
+			field.omitEmpty = input && !typ.NonNull
+			fields = append(fields, field)
+			return nil
+		}
+		for _, field := range def.Fields {
+			if err := add(field.Name, field.Type, false); err != nil {
+				return "", err
+			}
+		}
+		for _, field := range def.InputFields {
+			if err := add(field.Name, field.Type, true); err != nil {
+				return "", err
+			}
+		}
+		addImports(fields)
+		writeStruct(&b, def.Description, config.Go, fields, "")
+	}
+
+	for _, op := range operations {
+		if op.Input != "" {
+			addImports(op.args)
+			writeStruct(&b, "", op.Input, op.args, "")
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString("package types\n")
+	if len(imports) > 0 {
+		packages := slices.Sorted(maps.Keys(imports))
+		file.WriteString("\nimport (\n")
+		for _, pkg := range packages {
+			fmt.Fprintf(&file, "%q\n", pkg)
+		}
+		file.WriteString(")\n")
+	}
+	file.Write(b.Bytes())
+	return file.String(), nil
+}
+
+func (g *generator) documentsFile(pkg string, typ graphql.OperationType, operations []*operation) (string, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\nconst (\n", pkg)
+	for _, op := range operations {
+		if op.typ == typ {
+			fmt.Fprintf(&b, "%s = `%s`\n\n", op.Const, op.document)
+		}
+	}
+	b.WriteString(")\n")
+	return b.String(), nil
+}
+
+func (g *generator) clientFile(operations []*operation) (string, error) {
+	var body bytes.Buffer
+	for _, op := range operations {
+		if op.Input == "" && len(op.args) > 0 {
+			writeStruct(&body, "", variablesName(op), op.args, "types.")
+		}
+	}
+
+	for _, op := range operations {
+		if op.typ == graphql.OperationSubscription {
+			continue
+		}
+		typ, pointer, err := g.resultType(op)
+		if err != nil {
+			return "", err
+		}
+		typ = qualify(typ, "types.")
+
+		var params, variables string
+		switch {
+		case op.Input != "":
+			params, variables = "input types."+op.Input, "input"
+		case len(op.args) == 0:
+			variables = "nil"
+		case op.Method == "":
+			params, variables = "variables "+variablesName(op), "variables"
+		default:
+			var names, values []string
+			for _, arg := range op.args {
+				param := unexportedName(arg.name)
+				names = append(names, param+" "+qualify(arg.typ, "types."))
+				values = append(values, arg.name+": "+param)
+			}
+			params = strings.Join(names, ", ")
+			variables = variablesName(op) + "{" + strings.Join(values, ", ") + "}"
+		}
+
+		// Unexported methods back hand-written wrappers, which may pass
+		// request options such as WithContext through.
+		name, opts := op.Method, ""
+		if name == "" {
+			name = "do" + exportedName(op.Field)
+			params, opts = strings.TrimPrefix(params+", opts ...RequestOption", ", "), ", opts..."
+		}
+		if op.def.Description != "" {
+			writeComment(&body, op.def.Description)
+		}
+		document := documentConst(op)
+		send := fmt.Sprintf("SendRequestTyped[%s](c, %q, %s, %s%s)", typ, op.Field, document, variables, opts)
+		if pointer {
+			fmt.Fprintf(&body, "\nfunc (c *Client) %s(%s) (*%s, error) {\nresult, err := %s\nif err != nil {\nreturn nil, err\n}\nreturn &result, nil\n}\n", name, params, typ, send)
+		} else {
+			fmt.Fprintf(&body, "\nfunc (c *Client) %s(%s) (%s, error) {\nreturn %s\n}\n", name, params, typ, send)
+		}
+	}
+
+	body.WriteString("\nvar operationBindings = []operationBinding{\n")
+	for _, op := range operations {
+		document := documentConst(op)
+		input := "nil"
+		switch {
+		case op.Input != "":
+			input = "types." + op.Input + "{}"
+		case len(op.args) > 0:
+			input = variablesName(op) + "{}"
+		}
+		fmt.Fprintf(&body, "{%q, %s, %s},\n", op.Field, document, input)
+	}
+	body.WriteString("}\n")
+
+	body.WriteString("\nvar persistedQueryHashes = map[string]string{\n")
+	for _, op := range operations {
+		if op.typ == graphql.OperationSubscription {
+			continue
+		}
+		sum := sha256.Sum256([]byte(op.document))
+		fmt.Fprintf(&body, "%s: %q,\n", documentConst(op), hex.EncodeToString(sum[:]))
+	}
+	body.WriteString("}\n")
+
+	var b bytes.Buffer
+	b.WriteString("package cashrampsdk\n\nimport (\n")
+	for _, pkg := range []string{"mutations", "queries", "subscriptions", "types"} {
+		if strings.Contains(body.String(), pkg+".") {
+			fmt.Fprintf(&b, "%q\n", g.config.Module+"/"+pkg)
+		}
+	}
+	b.WriteString(")\n")
+	b.Write(body.Bytes())
+	return b.String(), nil
+}
+
+// documentConst returns the qualified name of the operation's document.
+func documentConst(op *operation) string {
+	switch op.typ {
+	case graphql.OperationMutation:
+		return "mutations." + op.Const
+	case graphql.OperationSubscription:
+		return "subscriptions." + op.Const
+	}
+	return "queries." + op.Const
+}
+
+func operationName(op *operation) string {
+	if op.Operation != "" {
+		return op.Operation
+	}
+	var name strings.Builder
+	for _, word := range strings.Split(strings.ToLower(op.Const), "_") {
+		name.WriteString(exportedName(word))
+	}
+	return name.String()
+}
+
+func variablesName(op *operation) string {
+	if op.Variables != "" {
+		return op.Variables
+	}
+	return op.Field + "Variables"
+}
+
+func writeStruct(b *bytes.Buffer, description, name string, fields []structField, pkg string) {
+	b.WriteString("\n")
+	if description != "" {
+		writeComment(b, description)
+	}
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, field := range fields {
+		tag := field.jsonName
+		if field.omitEmpty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "%s %s `json:%q`\n", field.name, qualify(field.typ, pkg), tag)
+	}
+	b.WriteString("}\n")
+}
+
+func writeComment(b *bytes.Buffer, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(b, "// %s\n", strings.TrimRight(line, " "))
+	}
+}
+
+// qualify prefixes the named type in a Go type expression with pkg, unless
+// it is predeclared or already qualified.
+func qualify(typ, pkg string) string {
+	base := strings.TrimLeft(typ, "[]*")
+	if pkg == "" || gotypes.Universe.Lookup(base) != nil || strings.Contains(base, ".") {
+		return typ
+	}
+	return typ[:len(typ)-len(base)] + pkg + base
+}
+
+func exportedName(name string) string {
+	if name == "id" {
+		return "ID"
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func unexportedName(name string) string {
+	if strings.ToUpper(name) == name {
+		return strings.ToLower(name)
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,83 @@
+package codegen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/rockets-hq/cashramp-sdk/internal/codegen"
+	"github.com/stretchr/testify/assert"
+)
+
+const root = "../.."
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	schema, err := graphql.LoadSchema(filepath.Join(root, "schema", "schema.graphql"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	config, err := codegen.LoadConfig(filepath.Join(root, "schema", "codegen.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	files, err := codegen.Generate(schema, config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, files, 5)
+	for name, generated := range files {
+		onDisk, err := os.ReadFile(filepath.Join(root, name))
+		if assert.NoError(t, err) {
+			assert.Equal(t, string(generated), string(onDisk), "%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	schema, err := graphql.ParseSchema(`
+		scalar Money
+		type Query {
+			balance: Money!
+			wallet: Wallet!
+			wallets(first: Int, after: String!): [Wallet!]!
+		}
+		type Wallet { id: ID! }
+	`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = codegen.Generate(schema, &codegen.Config{
+		Operations: []codegen.OperationConfig{{Field: "missing", Const: "MISSING"}},
+	})
+	assert.ErrorContains(t, err, `no query, mutation or subscription field "missing"`)
+
+	_, err = codegen.Generate(schema, &codegen.Config{
+		Operations: []codegen.OperationConfig{{Field: "balance", Const: "BALANCE"}},
+	})
+	assert.ErrorContains(t, err, "scalar Money has no Go type")
+
+	_, err = codegen.Generate(schema, &codegen.Config{
+		Operations: []codegen.OperationConfig{{Field: "wallet", Const: "WALLET"}},
+	})
+	assert.ErrorContains(t, err, "type Wallet has no Go name")
+
+	files, err := codegen.Generate(schema, &codegen.Config{
+		Module:  "example.com/sdk",
+		Scalars: map[string]string{"Money": "float64"},
+		Types:   []codegen.TypeConfig{{GraphQL: "Wallet", Go: "Wallet", Fields: map[string]codegen.FieldConfig{"id": {Name: "WalletID"}}}},
+		Operations: []codegen.OperationConfig{
+			{Field: "wallet", Const: "WALLET", Method: "GetWallet"},
+			{Field: "wallets", Const: "WALLETS"},
+		},
+	})
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(files[codegen.ClientFile]), "First int    `json:\"first,omitempty\"`")
+		assert.Contains(t, string(files[codegen.ClientFile]), "After string `json:\"after\"`")
+		assert.Contains(t, string(files[codegen.TypesFile]), "WalletID string `json:\"id\"`")
+		assert.Contains(t, string(files[codegen.ClientFile]), "func (c *Client) GetWallet() (*types.Wallet, error)")
+		assert.Contains(t, string(files[codegen.QueriesFile]), "WALLET = `query Wallet {\n  wallet {\n    id\n  }\n}`")
+	}
+}
@@ -0,0 +1,94 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config maps a schema onto the SDK's Go API. Lists are used where output
+// order matters, so regenerating is deterministic and diffs stay small.
+type Config struct {
+	// Module is the SDK's import path.
+	Module string `json:"module"`
+	// Scalars maps custom scalars and enums to Go types, written as for
+	// FieldConfig.Type. Unmapped enums become strings; unmapped custom
+	// scalars are an error.
+	Scalars map[string]string `json:"scalars"`
+	// Types maps object and input types to Go struct names, in the order
+	// they are written out.
+	Types []TypeConfig `json:"types"`
+	// Operations lists the root fields the SDK calls, in the order their
+	// documents and methods are written out.
+	Operations []OperationConfig `json:"operations"`
+}
+
+type TypeConfig struct {
+	GraphQL string `json:"graphql"`
+	Go      string `json:"go"`
+	// External marks a type that is written by hand, so it is referenced
+	// but not generated.
+	External bool                   `json:"external"`
+	Fields   map[string]FieldConfig `json:"fields"`
+	// Omit lists fields that are neither selected nor generated.
+	Omit []string `json:"omit"`
+}
+
+// FieldConfig overrides the Go name or type derived for a field or argument.
+// Type is a Go type expression using names from the types package or
+// qualified standard library names such as time.Time.
+type FieldConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type OperationConfig struct {
+	// Field is the root field on the query or mutation type.
+	Field string `json:"field"`
+	// Const names the document constant in the queries or mutations package.
+	Const string `json:"const"`
+	// Operation names the GraphQL operation, defaulting to Const in
+	// PascalCase.
+	Operation string `json:"operation"`
+	// Method, if set, generates an exported Client method. Operations
+	// without one get only the unexported do method, for hand-written
+	// wrappers that validate first.
+	Method string `json:"method"`
+	// Input names an exported struct in the types package that carries the
+	// arguments. Without one, arguments are carried by an unexported struct
+	// named Variables, defaulting to <field>Variables.
+	Input     string                 `json:"input"`
+	Variables string                 `json:"variables"`
+	Args      map[string]FieldConfig `json:"args"`
+}
+
+// LoadConfig reads a JSON config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("codegen: decoding %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func (c *Config) typeConfig(graphqlName string) *TypeConfig {
+	for i := range c.Types {
+		if c.Types[i].GraphQL == graphqlName {
+			return &c.Types[i]
+		}
+	}
+	return nil
+}
+
+func (t *TypeConfig) omits(field string) bool {
+	for _, omitted := range t.Omit {
+		if omitted == field {
+			return true
+		}
+	}
+	return false
+}
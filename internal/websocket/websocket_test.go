@@ -0,0 +1,150 @@
+package websocket_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/internal/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, server *httptest.Server, protocols ...string) *websocket.Conn {
+	header := http.Header{}
+	if len(protocols) > 0 {
+		header.Set("Sec-WebSocket-Protocol", strings.Join(protocols, ", "))
+	}
+	conn, err := websocket.Dial(context.Background(), wsURL(server), header)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return conn
+}
+
+func TestEcho(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, []string{"graphql-transport-ws"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		assert.NoError(t, conn.WriteMessage(websocket.PingMessage, []byte("are you there")))
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				var closeErr *websocket.CloseError
+				assert.ErrorAs(t, err, &closeErr)
+				assert.Equal(t, websocket.CloseNormalClosure, closeErr.Code)
+				return
+			}
+			assert.NoError(t, conn.WriteMessage(messageType, message))
+		}
+	}))
+	defer server.Close()
+
+	conn := dial(t, server, "other", "graphql-transport-ws")
+	defer conn.Close()
+	assert.Equal(t, "graphql-transport-ws", conn.Subprotocol())
+
+	for _, size := range []int{0, 5, 125, 126, 300, 70000} {
+		message := []byte(strings.Repeat("x", size))
+		assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, message))
+		messageType, echoed, err := conn.ReadMessage()
+		assert.NoError(t, err)
+		assert.Equal(t, websocket.BinaryMessage, messageType)
+		assert.Equal(t, string(message), string(echoed))
+	}
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	messageType, echoed, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	assert.Equal(t, "hello", string(echoed))
+
+	assert.NoError(t, conn.WriteClose(websocket.CloseNormalClosure, "bye"))
+	assert.ErrorIs(t, conn.WriteMessage(websocket.TextMessage, []byte("late")), websocket.ErrCloseSent)
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if assert.ErrorAs(t, err, &closeErr) {
+		assert.Equal(t, websocket.CloseNormalClosure, closeErr.Code)
+	}
+}
+
+func TestServerClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		conn.WriteClose(4401, "Unauthorized")
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+	assert.Empty(t, conn.Subprotocol())
+	_, _, err := conn.ReadMessage()
+	assert.EqualError(t, err, "websocket: close 4401 Unauthorized")
+}
+
+func TestHandshakeErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := websocket.Dial(context.Background(), wsURL(server), nil)
+	var handshakeErr *websocket.HandshakeError
+	if assert.ErrorAs(t, err, &handshakeErr) {
+		assert.Equal(t, http.StatusUnauthorized, handshakeErr.StatusCode)
+	}
+
+	_, err = websocket.Dial(context.Background(), "http://example.com", nil)
+	assert.ErrorContains(t, err, "unsupported scheme")
+
+	upgradeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := websocket.Upgrade(w, r, nil)
+		assert.Error(t, err)
+	}))
+	defer upgradeServer.Close()
+	resp, err := http.Get(upgradeServer.URL)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	}
+}
+
+func TestDialContextCancelled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// Accept and never answer the handshake.
+		conn, err := listener.Accept()
+		if err == nil {
+			<-done
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = websocket.Dial(ctx, "ws://"+listener.Addr().String(), nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error %v", err)
+}
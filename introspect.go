@@ -0,0 +1,27 @@
+package cashrampsdk
+
+import (
+	"encoding/json"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+)
+
+// IntrospectJSON fetches the server's schema with the standard introspection
+// query and returns it as {"__schema": ...}, the form graphql.LoadSchema,
+// graphql.SchemaFromIntrospection and cashrampgen read from .json snapshots.
+func (c *Client) IntrospectJSON() ([]byte, error) {
+	schema, err := SendRequestTyped[json.RawMessage](c, "__schema", graphql.IntrospectionQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(map[string]json.RawMessage{"__schema": schema}, "", "  ")
+}
+
+// Introspect fetches and loads the server's schema.
+func (c *Client) Introspect() (*graphql.Schema, error) {
+	data, err := c.IntrospectJSON()
+	if err != nil {
+		return nil, err
+	}
+	return graphql.SchemaFromIntrospection(data)
+}
@@ -0,0 +1,77 @@
+package cashrampsdk
+
+import (
+	"iter"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// ListLedgerEntries iterates over the account's ledger entries matching
+// filter: deposits, withdrawals, fees and payment settlements.
+func (c *Client) ListLedgerEntries(filter types.LedgerFilter, opts ...ListOption) iter.Seq2[types.LedgerEntry, error] {
+	options := newListOptions(opts)
+	return Paginate(func(cursor string) ([]types.LedgerEntry, types.PageInfo, error) {
+		if cursor == "" {
+			if err := filter.Validate(); err != nil {
+				return nil, types.PageInfo{}, err
+			}
+		}
+		page, err := c.doAccountLedger(accountLedgerVariables{Filter: filter, First: options.pageSize, After: cursor})
+		if err != nil {
+			return nil, types.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+}
+
+// LedgerReconciliation compares the balance the ledger adds up to with the
+// account balance reported by GetAccount.
+type LedgerReconciliation struct {
+	// Entries are the ledger entries in the order they were created, each
+	// with the running balance after it.
+	Entries        []types.LedgerBalance
+	Closing        types.Decimal
+	AccountBalance types.Decimal
+	// Difference is AccountBalance minus Closing.
+	Difference types.Decimal
+}
+
+// Balanced reports whether the ledger adds up to the account balance.
+func (r *LedgerReconciliation) Balanced() bool {
+	return r.Difference.IsZero()
+}
+
+// ReconcileLedger fetches every ledger entry created since the given time,
+// applies them to opening, the balance at that time, and compares the result
+// with the current account balance. Pass the zero time and a zero opening
+// balance to reconcile the whole history. Entries created while it runs can
+// make a correct ledger appear unbalanced, so retry before investigating.
+func (c *Client) ReconcileLedger(opening types.Decimal, since time.Time) (*LedgerReconciliation, error) {
+	var entries []types.LedgerEntry
+	for entry, err := range c.ListLedgerEntries(types.LedgerFilter{CreatedAfter: since}) {
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	// Decode the balance straight into a Decimal; going through the float64
+	// in types.Account would round large or high-precision balances.
+	account, err := GetAccountAs[struct {
+		AccountBalance types.Decimal `json:"accountBalance"`
+	}](c)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &LedgerReconciliation{
+		Entries:        types.RunningBalances(opening, entries),
+		Closing:        opening,
+		AccountBalance: account.AccountBalance,
+	}
+	if n := len(reconciliation.Entries); n > 0 {
+		reconciliation.Closing = reconciliation.Entries[n-1].Balance
+	}
+	reconciliation.Difference = reconciliation.AccountBalance.Sub(reconciliation.Closing)
+	return reconciliation, nil
+}
@@ -0,0 +1,111 @@
+package cashrampsdk_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockLedgerServer serves the given ledger entries one per page, and the
+// account with the given balance.
+func mockLedgerServer(t *testing.T, accountBalance any, entries ...map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			OperationName string `json:"operationName"`
+			Variables     struct {
+				Filter map[string]any `json:"filter"`
+				After  string         `json:"after"`
+			} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch body.OperationName {
+		case "Account":
+			w.Write(createMockGraphQLResponse(t, "account", map[string]any{"id": "acc_1", "accountBalance": accountBalance}))
+		case "AccountLedger":
+			assert.Equal(t, map[string]any{"createdAfter": "2026-03-01T00:00:00Z"}, body.Variables.Filter)
+			index := 0
+			if body.Variables.After != "" {
+				index, _ = strconv.Atoi(body.Variables.After)
+			}
+			w.Write(createMockGraphQLResponse(t, "accountLedger", map[string]any{
+				"nodes":    entries[index : index+1],
+				"pageInfo": map[string]any{"hasNextPage": index+1 < len(entries), "endCursor": strconv.Itoa(index + 1)},
+			}))
+		default:
+			t.Errorf("unexpected operation %s", body.OperationName)
+		}
+	}))
+}
+
+var testLedgerEntries = []map[string]any{
+	{"id": "le_1", "type": "deposit", "amount": "100.10", "createdAt": "2026-03-01T09:00:00Z"},
+	{"id": "le_2", "type": "payment_settlement", "amount": "25.05", "reference": "order_42", "createdAt": "2026-03-02T09:00:00Z"},
+	{"id": "le_3", "type": "fee", "amount": "-0.15", "createdAt": "2026-03-02T09:00:01Z"},
+}
+
+var march = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestListLedgerEntries(t *testing.T) {
+	server := mockLedgerServer(t, 0, testLedgerEntries...)
+	defer server.Close()
+
+	var entries []types.LedgerEntry
+	for entry, err := range dummyClient(t, server).ListLedgerEntries(types.LedgerFilter{CreatedAfter: march}) {
+		assert.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, types.LedgerEntryPaymentSettlement, entries[1].Type)
+		assert.Equal(t, "order_42", entries[1].Reference)
+		assert.Equal(t, "25.05", entries[1].Amount.String())
+		assert.Equal(t, time.Date(2026, 3, 2, 9, 0, 1, 0, time.UTC), entries[2].CreatedAt)
+	}
+
+	for _, err := range dummyClient(t, server).ListLedgerEntries(types.LedgerFilter{Type: "refund"}) {
+		assert.ErrorContains(t, err, `"refund" is not a known ledger entry type`)
+	}
+}
+
+func TestReconcileLedger(t *testing.T) {
+	server := mockLedgerServer(t, 135, testLedgerEntries...)
+	defer server.Close()
+
+	reconciliation, err := dummyClient(t, server).ReconcileLedger(types.MustParseDecimal("10"), march)
+	assert.NoError(t, err)
+	assert.True(t, reconciliation.Balanced())
+	assert.Equal(t, "135", reconciliation.Closing.String())
+	var running []string
+	for _, entry := range reconciliation.Entries {
+		running = append(running, entry.Balance.String())
+	}
+	assert.Equal(t, "110.1 135.15 135", strings.Join(running, " "))
+}
+
+func TestReconcileLedgerUnbalanced(t *testing.T) {
+	server := mockLedgerServer(t, 130.5, testLedgerEntries...)
+	defer server.Close()
+
+	reconciliation, err := dummyClient(t, server).ReconcileLedger(types.MustParseDecimal("10"), march)
+	assert.NoError(t, err)
+	assert.False(t, reconciliation.Balanced())
+	assert.Equal(t, "-4.5", reconciliation.Difference.String())
+}
+
+func TestReconcileLedgerLargeBalance(t *testing.T) {
+	// Too precise for a float64, which would round it to 12345678901234568.
+	server := mockLedgerServer(t, json.Number("12345678901234567.89"), testLedgerEntries...)
+	defer server.Close()
+
+	reconciliation, err := dummyClient(t, server).ReconcileLedger(types.MustParseDecimal("12345678901234442.89"), march)
+	assert.NoError(t, err)
+	assert.True(t, reconciliation.Balanced())
+	assert.Equal(t, "12345678901234567.89", reconciliation.AccountBalance.String())
+}
@@ -0,0 +1,77 @@
+package cashrampsdk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rockets-hq/cashramp-sdk/limits"
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// hostedPaymentAmountUsd converts a hosted payment amount to USD for limit
+// checks, using the market rate side that applies to its payment type. The
+// market rate only converts the country's own currency, so any other
+// currency is rejected.
+func (c *Client) hostedPaymentAmountUsd(payment types.InitiateHostedPaymentInput) (float64, error) {
+	if payment.Currency == "" || payment.Currency == types.CurrencyCodeUSD {
+		return payment.Amount, nil
+	}
+	if local, ok := payment.CountryCode.Currency(); !ok || payment.Currency != local {
+		return 0, &types.ValidationError{Errors: []types.FieldError{{
+			Field:   "currency",
+			Message: fmt.Sprintf("%q is neither USD nor the local currency of %s", payment.Currency, payment.CountryCode),
+		}}}
+	}
+
+	marketRate, err := c.GetMarketRate(payment.CountryCode)
+	if err != nil {
+		return 0, err
+	}
+	rate, err := marketRate.RateFor(payment.PaymentType)
+	if err != nil {
+		return 0, err
+	}
+	return payment.Amount / rate, nil
+}
+
+// reserveHostedPaymentLimits checks payment against the limits guard, if
+// any, and reserves its amount towards the daily limit.
+func (c *Client) reserveHostedPaymentLimits(payment types.InitiateHostedPaymentInput) (limits.Reservation, error) {
+	if c.limits == nil {
+		return limits.Reservation{}, nil
+	}
+
+	amountUsd, err := c.hostedPaymentAmountUsd(payment)
+	if err != nil {
+		return limits.Reservation{}, err
+	}
+	return c.limits.Reserve(payment.PaymentType, amountUsd)
+}
+
+func (c *Client) reserveWithdrawalLimits(payment types.WithdrawOnchainInput) (limits.Reservation, error) {
+	if c.limits == nil {
+		return limits.Reservation{}, nil
+	}
+
+	amountUsd, err := strconv.ParseFloat(payment.Amount, 64)
+	if err != nil {
+		return limits.Reservation{}, err
+	}
+	return c.limits.Reserve(types.PaymentTypeWithdrawal, amountUsd)
+}
+
+// releaseLimits gives back a reservation when the API rejected the request
+// it was made for, with a GraphQL error or a 4xx status. After a network
+// error or a 5xx status the request may still have gone through, so the
+// reservation is kept.
+func (c *Client) releaseLimits(reservation limits.Reservation, requestErr error) error {
+	var rejected *requestError
+	if c.limits == nil || !errors.As(requestErr, &rejected) || rejected.statusCode >= 500 {
+		return requestErr
+	}
+	if err := c.limits.Release(reservation); err != nil {
+		return errors.Join(requestErr, err)
+	}
+	return requestErr
+}
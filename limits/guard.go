@@ -0,0 +1,204 @@
+// Package limits enforces Cashramp's onchain ramp limits locally, before a
+// request that the API would reject is sent.
+package limits
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+type Limit string
+
+const (
+	LimitMinimumDeposit    Limit = "minimum deposit"
+	LimitMaximumDeposit    Limit = "maximum deposit"
+	LimitMinimumWithdrawal Limit = "minimum withdrawal"
+	LimitMaximumWithdrawal Limit = "maximum withdrawal"
+	LimitDaily             Limit = "daily limit"
+)
+
+// LimitExceededError reports which limit an amount breaks. UsedUsd is only
+// set for the daily limit.
+type LimitExceededError struct {
+	Limit       Limit
+	PaymentType types.PaymentType
+	AmountUsd   float64
+	LimitUsd    float64
+	UsedUsd     float64
+}
+
+func (e *LimitExceededError) Error() string {
+	switch e.Limit {
+	case LimitMinimumDeposit, LimitMinimumWithdrawal:
+		return fmt.Sprintf("%s of %.2f USD is below the %s of %.2f USD", e.PaymentType, e.AmountUsd, e.Limit, e.LimitUsd)
+	case LimitDaily:
+		return fmt.Sprintf("%s of %.2f USD would exceed the daily limit of %.2f USD (%.2f USD already used today)", e.PaymentType, e.AmountUsd, e.LimitUsd, e.UsedUsd)
+	default:
+		return fmt.Sprintf("%s of %.2f USD is above the %s of %.2f USD", e.PaymentType, e.AmountUsd, e.Limit, e.LimitUsd)
+	}
+}
+
+// FetchFunc loads the current ramp limits, typically Client.GetRampLimits.
+type FetchFunc func() (*types.RampLimits, error)
+
+// Guard checks amounts against cached ramp limits and tracks daily usage in a
+// Store. Use Reserve, which checks and records in one step, so that
+// concurrent requests cannot together exceed the daily limit; Check followed
+// by Record leaves a window in which they can.
+type Guard struct {
+	store Store
+	fetch FetchFunc
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	limits    *types.RampLimits
+	fetchedAt time.Time
+
+	// reserveMu makes Reserve atomic for stores that are not ReservingStores.
+	reserveMu sync.Mutex
+}
+
+// NewGuard returns a guard that refetches limits once they are older than
+// ttl.
+func NewGuard(store Store, fetch FetchFunc, ttl time.Duration) *Guard {
+	return &Guard{store: store, fetch: fetch, ttl: ttl, now: time.Now}
+}
+
+// Limits returns the cached ramp limits, refetching them when stale.
+func (g *Guard) Limits() (*types.RampLimits, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.limits != nil && g.now().Sub(g.fetchedAt) < g.ttl {
+		return g.limits, nil
+	}
+	limits, err := g.fetch()
+	if err != nil {
+		return nil, err
+	}
+	g.limits, g.fetchedAt = limits, g.now()
+	return limits, nil
+}
+
+// Check returns a *LimitExceededError if amountUsd breaks the per-request
+// limits for paymentType or would take today's usage over the daily limit.
+// Zero limits are treated as unset.
+func (g *Guard) Check(paymentType types.PaymentType, amountUsd float64) error {
+	limits, err := g.Limits()
+	if err != nil {
+		return err
+	}
+	if err := checkRequest(limits, paymentType, amountUsd); err != nil {
+		return err
+	}
+
+	if limits.DailyLimitUsd > 0 {
+		used, err := g.store.Used(g.now())
+		if err != nil {
+			return err
+		}
+		if used+amountUsd > limits.DailyLimitUsd {
+			return dailyLimitExceeded(limits, paymentType, amountUsd, used)
+		}
+	}
+	return nil
+}
+
+// Record adds amountUsd to today's usage.
+func (g *Guard) Record(amountUsd float64) error {
+	return g.store.Record(g.now(), amountUsd)
+}
+
+// Reservation is usage recorded by Reserve, to be released if the request it
+// was made for fails.
+type Reservation struct {
+	day       time.Time
+	amountUsd float64
+}
+
+// Reserve checks amountUsd like Check and, if it is within the limits,
+// records it towards today's usage in the same step. Release the reservation
+// if the request is then rejected.
+func (g *Guard) Reserve(paymentType types.PaymentType, amountUsd float64) (Reservation, error) {
+	limits, err := g.Limits()
+	if err != nil {
+		return Reservation{}, err
+	}
+	if err := checkRequest(limits, paymentType, amountUsd); err != nil {
+		return Reservation{}, err
+	}
+
+	now := g.now()
+	limitUsd := limits.DailyLimitUsd
+	if limitUsd <= 0 {
+		limitUsd = math.Inf(1)
+	}
+	var used float64
+	var ok bool
+	if store, reserving := g.store.(ReservingStore); reserving {
+		used, ok, err = store.Reserve(now, amountUsd, limitUsd)
+	} else {
+		used, ok, err = g.reserveLocked(now, amountUsd, limitUsd)
+	}
+	if err != nil {
+		return Reservation{}, err
+	}
+	if !ok {
+		return Reservation{}, dailyLimitExceeded(limits, paymentType, amountUsd, used)
+	}
+	return Reservation{day: now, amountUsd: amountUsd}, nil
+}
+
+func (g *Guard) reserveLocked(t time.Time, amountUsd, limitUsd float64) (float64, bool, error) {
+	g.reserveMu.Lock()
+	defer g.reserveMu.Unlock()
+
+	used, err := g.store.Used(t)
+	if err != nil {
+		return 0, false, err
+	}
+	if used+amountUsd > limitUsd {
+		return used, false, nil
+	}
+	return used, true, g.store.Record(t, amountUsd)
+}
+
+// Release removes a reservation from the usage of the day it was made.
+// Releasing the zero Reservation does nothing.
+func (g *Guard) Release(reservation Reservation) error {
+	if reservation.amountUsd == 0 {
+		return nil
+	}
+	return g.store.Record(reservation.day, -reservation.amountUsd)
+}
+
+// checkRequest returns a *LimitExceededError if amountUsd is outside the
+// per-request limits for paymentType.
+func checkRequest(limits *types.RampLimits, paymentType types.PaymentType, amountUsd float64) error {
+	minimum, maximum := limits.MinimumDepositUsd, limits.MaximumDepositUsd
+	minimumLimit, maximumLimit := LimitMinimumDeposit, LimitMaximumDeposit
+	if paymentType == types.PaymentTypeWithdrawal {
+		minimum, maximum = limits.MinimumWithdrawalUsd, limits.MaximumWithdrawalUsd
+		minimumLimit, maximumLimit = LimitMinimumWithdrawal, LimitMaximumWithdrawal
+	}
+
+	exceeded := &LimitExceededError{PaymentType: paymentType, AmountUsd: amountUsd}
+	switch {
+	case minimum > 0 && amountUsd < minimum:
+		exceeded.Limit, exceeded.LimitUsd = minimumLimit, minimum
+		return exceeded
+	case maximum > 0 && amountUsd > maximum:
+		exceeded.Limit, exceeded.LimitUsd = maximumLimit, maximum
+		return exceeded
+	}
+	return nil
+}
+
+func dailyLimitExceeded(limits *types.RampLimits, paymentType types.PaymentType, amountUsd, used float64) *LimitExceededError {
+	return &LimitExceededError{Limit: LimitDaily, PaymentType: paymentType, AmountUsd: amountUsd, LimitUsd: limits.DailyLimitUsd, UsedUsd: used}
+}
@@ -0,0 +1,134 @@
+package limits
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardCheck(t *testing.T) {
+	fetches := 0
+	guard := NewGuard(NewMemoryStore(), func() (*types.RampLimits, error) {
+		fetches++
+		return &types.RampLimits{
+			MinimumDepositUsd:    5,
+			MaximumDepositUsd:    1000,
+			MinimumWithdrawalUsd: 10,
+			MaximumWithdrawalUsd: 500,
+			DailyLimitUsd:        600,
+		}, nil
+	}, time.Minute)
+
+	var exceeded *LimitExceededError
+	assert.ErrorAs(t, guard.Check(types.PaymentTypeWithdrawal, 5), &exceeded)
+	assert.Equal(t, LimitMinimumWithdrawal, exceeded.Limit)
+	assert.Equal(t, 10.0, exceeded.LimitUsd)
+
+	assert.ErrorAs(t, guard.Check(types.PaymentTypeDeposit, 1500), &exceeded)
+	assert.Equal(t, LimitMaximumDeposit, exceeded.Limit)
+
+	assert.NoError(t, guard.Check(types.PaymentTypeDeposit, 400))
+	assert.NoError(t, guard.Record(400))
+
+	assert.ErrorAs(t, guard.Check(types.PaymentTypeWithdrawal, 250), &exceeded)
+	assert.Equal(t, LimitDaily, exceeded.Limit)
+	assert.Equal(t, 400.0, exceeded.UsedUsd)
+	assert.Contains(t, exceeded.Error(), "400.00 USD already used today")
+
+	assert.Equal(t, 1, fetches)
+}
+
+// plainStore hides MemoryStore's Reserve, leaving Guard to serialise
+// reservations itself.
+type plainStore struct{ Store }
+
+func TestGuardReserveConcurrently(t *testing.T) {
+	for name, store := range map[string]Store{
+		"reserving store": NewMemoryStore(),
+		"file store":      NewFileStore(filepath.Join(t.TempDir(), "usage.json")),
+		"plain store":     plainStore{NewMemoryStore()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			guard := NewGuard(store, func() (*types.RampLimits, error) {
+				return &types.RampLimits{DailyLimitUsd: 600}, nil
+			}, time.Minute)
+
+			var wg sync.WaitGroup
+			var reserved atomic.Int32
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := guard.Reserve(types.PaymentTypeWithdrawal, 100)
+					var exceeded *LimitExceededError
+					if err == nil {
+						reserved.Add(1)
+					} else if assert.ErrorAs(t, err, &exceeded) {
+						assert.Equal(t, LimitDaily, exceeded.Limit)
+					}
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int32(6), reserved.Load())
+
+			used, err := store.Used(time.Now())
+			assert.NoError(t, err)
+			assert.Equal(t, 600.0, used)
+		})
+	}
+}
+
+func TestGuardRelease(t *testing.T) {
+	store := NewMemoryStore()
+	guard := NewGuard(store, func() (*types.RampLimits, error) {
+		return &types.RampLimits{MinimumDepositUsd: 5, DailyLimitUsd: 100}, nil
+	}, time.Minute)
+
+	reservation, err := guard.Reserve(types.PaymentTypeDeposit, 80)
+	assert.NoError(t, err)
+	_, err = guard.Reserve(types.PaymentTypeDeposit, 30)
+	assert.Error(t, err)
+
+	assert.NoError(t, guard.Release(reservation))
+	_, err = guard.Reserve(types.PaymentTypeDeposit, 30)
+	assert.NoError(t, err)
+
+	_, err = guard.Reserve(types.PaymentTypeDeposit, 1)
+	var exceeded *LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, LimitMinimumDeposit, exceeded.Limit)
+	used, _ := store.Used(time.Now())
+	assert.Equal(t, 30.0, used)
+
+	assert.NoError(t, guard.Release(Reservation{}))
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	day := time.Date(2026, 3, 1, 23, 45, 0, 0, time.UTC)
+
+	store := NewFileStore(path)
+	assert.NoError(t, store.Record(day, 100))
+	assert.NoError(t, store.Record(day.Add(30*time.Minute), 50))
+
+	reopened := NewFileStore(path)
+	used, err := reopened.Used(day)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, used)
+
+	used, err = reopened.Used(day.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, used)
+
+	for i := 0; i < retainDays+5; i++ {
+		assert.NoError(t, store.Record(day.AddDate(0, 0, i+2), 1))
+	}
+	used, err = store.Used(day)
+	assert.NoError(t, err)
+	assert.Zero(t, used)
+}
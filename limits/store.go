@@ -0,0 +1,173 @@
+package limits
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store records how much USD volume has been ramped per UTC day.
+type Store interface {
+	// Used returns the volume recorded for the day containing t.
+	Used(t time.Time) (float64, error)
+	// Record adds amountUsd to the day containing t.
+	Record(t time.Time, amountUsd float64) error
+}
+
+// ReservingStore is a Store that can check and record usage in one step.
+// Guard.Reserve uses it when available, so that guards in separate clients
+// sharing the store cannot together exceed the daily limit.
+type ReservingStore interface {
+	Store
+	// Reserve adds amountUsd to the day containing t unless that would take
+	// the day's usage over limitUsd. It returns the usage before the call and
+	// whether amountUsd was added.
+	Reserve(t time.Time, amountUsd, limitUsd float64) (used float64, ok bool, err error)
+}
+
+func dayKey(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// MemoryStore keeps usage in process memory. Usage is lost on restart and is
+// not shared between processes.
+type MemoryStore struct {
+	mu    sync.Mutex
+	usage map[string]float64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{usage: map[string]float64{}}
+}
+
+func (m *MemoryStore) Used(t time.Time) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage[dayKey(t)], nil
+}
+
+func (m *MemoryStore) Record(t time.Time, amountUsd float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage[dayKey(t)] += amountUsd
+	return nil
+}
+
+func (m *MemoryStore) Reserve(t time.Time, amountUsd, limitUsd float64) (float64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	used := m.usage[dayKey(t)]
+	if used+amountUsd > limitUsd {
+		return used, false, nil
+	}
+	m.usage[dayKey(t)] += amountUsd
+	return used, true, nil
+}
+
+// FileStore keeps usage in a JSON file so it survives restarts. Only the most
+// recent retainDays days are kept. It is safe for concurrent use within a
+// process but not across processes sharing the file.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+const retainDays = 31
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (f *FileStore) Used(t time.Time) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usage, err := f.load()
+	if err != nil {
+		return 0, err
+	}
+	return usage[dayKey(t)], nil
+}
+
+func (f *FileStore) Record(t time.Time, amountUsd float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usage, err := f.load()
+	if err != nil {
+		return err
+	}
+	usage[dayKey(t)] += amountUsd
+	return f.save(usage)
+}
+
+func (f *FileStore) Reserve(t time.Time, amountUsd, limitUsd float64) (float64, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usage, err := f.load()
+	if err != nil {
+		return 0, false, err
+	}
+	used := usage[dayKey(t)]
+	if used+amountUsd > limitUsd {
+		return used, false, nil
+	}
+	usage[dayKey(t)] += amountUsd
+	return used, true, f.save(usage)
+}
+
+func (f *FileStore) load() (map[string]float64, error) {
+	usage := map[string]float64{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// save drops all but the most recent retainDays days from usage, then writes
+// it to a temporary file and renames it over the store so a crash mid-write
+// never leaves a truncated file behind.
+func (f *FileStore) save(usage map[string]float64) error {
+	days := make([]string, 0, len(usage))
+	for day := range usage {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	for len(days) > retainDays {
+		delete(usage, days[0])
+		days = days[1:]
+	}
+
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -0,0 +1,144 @@
+package cashrampsdk_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/limits"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsGuard(t *testing.T) {
+	server := mockGraphQLRouter(t, map[string]any{
+		"rampLimits": map[string]any{
+			"minimumDepositUsd":    1.0,
+			"maximumDepositUsd":    1000.0,
+			"minimumWithdrawalUsd": 10.0,
+			"maximumWithdrawalUsd": 500.0,
+			"dailyLimitUsd":        300.0,
+		},
+		"marketRate":            map[string]any{"depositRate": 1600.0, "withdrawalRate": 1500.0},
+		"initiateHostedPayment": map[string]any{"id": "1", "hostedLink": "https://payment-link.com", "status": "created"},
+		"withdrawOnchain":       map[string]any{"id": "wd_1", "status": "created"},
+	})
+	defer server.Close()
+
+	store := limits.NewMemoryStore()
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithLimitsGuard(store))
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	_, err = client.InitiateHostedPayment(types.InitiateHostedPaymentInput{
+		PaymentType: types.PaymentTypeDeposit,
+		Amount:      320000,
+		Currency:    types.CurrencyCodeNGN,
+		CountryCode: types.CountryCodeNG,
+		Reference:   "ref123",
+		FirstName:   "John",
+		LastName:    "Doe",
+		Email:       "john.doe@example.com",
+	})
+	assert.NoError(t, err)
+
+	_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "5"})
+	var exceeded *limits.LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, limits.LimitMinimumWithdrawal, exceeded.Limit)
+
+	_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "120"})
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, limits.LimitDaily, exceeded.Limit)
+	assert.Equal(t, 200.0, exceeded.UsedUsd)
+
+	_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "100"})
+	assert.NoError(t, err)
+}
+
+func TestLimitsGuardReleasesRejectedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if strings.Contains(string(body), "rampLimits") {
+			w.Write(createMockGraphQLResponse(t, "rampLimits", map[string]any{"dailyLimitUsd": 300.0}))
+			return
+		}
+		w.Write(createMockGraphQLResponse(t, "withdrawOnchain", nil, "insufficient balance"))
+	}))
+	defer server.Close()
+
+	store := limits.NewMemoryStore()
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithLimitsGuard(store))
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	for range 2 {
+		_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "250"})
+		assert.ErrorContains(t, err, "insufficient balance")
+	}
+	used, err := store.Used(time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, used)
+}
+
+func TestLimitsGuardKeepsReservationAfterServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if strings.Contains(string(body), "rampLimits") {
+			w.Write(createMockGraphQLResponse(t, "rampLimits", map[string]any{"dailyLimitUsd": 300.0}))
+			return
+		}
+		// The withdrawal may have gone through behind the gateway timeout.
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	store := limits.NewMemoryStore()
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithLimitsGuard(store))
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "250"})
+	assert.ErrorContains(t, err, "504")
+	used, err := store.Used(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 250.0, used)
+
+	_, err = client.WithdrawOnchain(types.WithdrawOnchainInput{Address: "0x123", Amount: "100"})
+	var exceeded *limits.LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+}
+
+func TestLimitsGuardRejectsForeignCurrency(t *testing.T) {
+	server := mockGraphQLRouter(t, map[string]any{
+		"rampLimits": map[string]any{"dailyLimitUsd": 300.0},
+	})
+	defer server.Close()
+
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithLimitsGuard(limits.NewMemoryStore()))
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	_, err = client.InitiateHostedPayment(types.InitiateHostedPaymentInput{
+		PaymentType: types.PaymentTypeDeposit,
+		Amount:      5000,
+		Currency:    types.CurrencyCodeKES,
+		CountryCode: types.CountryCodeNG,
+		Reference:   "ref123",
+		FirstName:   "John",
+		LastName:    "Doe",
+		Email:       "john.doe@example.com",
+	})
+	var validationErr *types.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		currencyErr, ok := validationErr.Field("currency")
+		assert.True(t, ok)
+		assert.Contains(t, currencyErr.Message, `"KES" is neither USD nor the local currency of NG`)
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by cashrampgen. DO NOT EDIT.
+
+package mutations
+
+const (
+	CONFIRM_TRANSACTION = `mutation ConfirmTransaction($paymentRequest: ID!, $transactionHash: String!) {
+  confirmTransaction(paymentRequest: $paymentRequest, transactionHash: $transactionHash)
+}`
+
+	INITIATE_HOSTED_PAYMENT = `mutation InitiateHostedPayment($paymentType: P2PPaymentTypeType!, $amount: Decimal!, $currency: P2PPaymentCurrency, $countryCode: String!, $reference: String!, $redirectUrl: String, $firstName: String!, $lastName: String!, $email: String!) {
+  initiateHostedPayment(paymentType: $paymentType, amount: $amount, currency: $currency, countryCode: $countryCode, reference: $reference, redirectUrl: $redirectUrl, firstName: $firstName, lastName: $lastName, email: $email) {
+    id
+    hostedLink
+    status
+  }
+}`
+
+	CANCEL_HOSTED_PAYMENT = `mutation CancelHostedPayment($paymentRequest: ID!) {
+  cancelHostedPayment(paymentRequest: $paymentRequest)
+}`
+
+	CREATE_CUSTOMER = `mutation CreateCustomer($email: String!, $firstName: String!, $lastName: String!, $country: ID!) {
+  createCustomer(email: $email, firstName: $firstName, lastName: $lastName, country: $country) {
+    id
+    email
+    firstName
+    lastName
+    country {
+      id
+      name
+      code
+    }
+  }
+}`
+
+	UPDATE_CUSTOMER = `mutation UpdateCustomer($customer: ID!, $email: String, $firstName: String, $lastName: String, $country: ID) {
+  updateCustomer(customer: $customer, email: $email, firstName: $firstName, lastName: $lastName, country: $country) {
+    id
+    email
+    firstName
+    lastName
+    country {
+      id
+      name
+      code
+    }
+  }
+}`
+
+	ADD_PAYMENT_METHOD = `mutation AddPaymentMethod($customer: ID!, $p2pPaymentMethodType: ID!, $fields: [P2PPaymentMethodFieldInput!]!) {
+  addPaymentMethod(customer: $customer, p2pPaymentMethodType: $p2pPaymentMethodType, fields: $fields) {
+    id
+    value
+    fields {
+      identifier
+      value
+    }
+    p2pPaymentMethodType {
+      id
+      identifier
+      label
+      fields {
+        label
+        identifier
+        required
+      }
+    }
+  }
+}`
+
+	REMOVE_PAYMENT_METHOD = `mutation RemovePaymentMethod($paymentMethod: ID!) {
+  removePaymentMethod(paymentMethod: $paymentMethod)
+}`
+
+	WITHDRAW_ONCHAIN = `mutation WithdrawOnchain($address: String!, $amountUsd: Decimal!) {
+  withdrawOnchain(address: $address, amountUsd: $amountUsd) {
+    id
+    status
+    address
+    network
+    transactionHash
+    amountUsd
+    feeUsd
+  }
+}`
+)
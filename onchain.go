@@ -0,0 +1,75 @@
+package cashrampsdk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rockets-hq/cashramp-sdk/onchain"
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// WithdrawOption configures a single WithdrawOnchain call.
+type WithdrawOption func(*withdrawOptions)
+
+type withdrawOptions struct {
+	network string
+	asset   *types.RampableAssets
+}
+
+// WithAddressNetwork rejects the withdrawal locally unless the destination
+// address is valid for network, e.g. "TRC20" or "CELO".
+func WithAddressNetwork(network string) WithdrawOption {
+	return func(o *withdrawOptions) {
+		o.network = network
+	}
+}
+
+// WithAddressAsset is like WithAddressNetwork but also requires network to be
+// one of the networks asset can be ramped on.
+func WithAddressAsset(asset types.RampableAssets, network string) WithdrawOption {
+	return func(o *withdrawOptions) {
+		o.network = network
+		o.asset = &asset
+	}
+}
+
+func (o *withdrawOptions) validate(address string) error {
+	if o.network == "" {
+		return nil
+	}
+	if o.asset != nil && !assetSupportsNetwork(*o.asset, o.network) {
+		return fmt.Errorf("%s is not available on %s, expected one of %s", o.asset.Symbol, o.network, strings.Join(o.asset.Networks, ", "))
+	}
+	return onchain.ValidateAddress(o.network, address)
+}
+
+func assetSupportsNetwork(asset types.RampableAssets, network string) bool {
+	for _, supported := range asset.Networks {
+		if strings.EqualFold(supported, network) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmOption configures a single ConfirmTransaction call.
+type ConfirmOption func(*confirmOptions)
+
+type confirmOptions struct {
+	network string
+}
+
+// WithTransactionNetwork rejects the confirmation locally unless the
+// transaction hash is well formed for network.
+func WithTransactionNetwork(network string) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.network = network
+	}
+}
+
+func (o *confirmOptions) validate(hash string) error {
+	if o.network == "" {
+		return nil
+	}
+	return onchain.ValidateTransactionHash(o.network, hash)
+}
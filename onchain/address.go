@@ -0,0 +1,214 @@
+// Package onchain validates blockchain addresses and transaction hashes for the
+// networks Cashramp ramps assets on.
+package onchain
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Format is an address and transaction encoding shared by a family of
+// networks.
+type Format string
+
+const (
+	FormatEVM     Format = "evm"
+	FormatTron    Format = "tron"
+	FormatSolana  Format = "solana"
+	FormatStellar Format = "stellar"
+)
+
+var (
+	ErrUnknownNetwork = errors.New("unknown network")
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+var networksMu sync.RWMutex
+
+// networkFormats maps the network names used in RampableAssets.Networks, and
+// their common aliases, to the address format they use.
+var networkFormats = map[string]Format{
+	"ETHEREUM":  FormatEVM,
+	"ERC20":     FormatEVM,
+	"CELO":      FormatEVM,
+	"POLYGON":   FormatEVM,
+	"MATIC":     FormatEVM,
+	"BSC":       FormatEVM,
+	"BEP20":     FormatEVM,
+	"BASE":      FormatEVM,
+	"ARBITRUM":  FormatEVM,
+	"OPTIMISM":  FormatEVM,
+	"AVALANCHE": FormatEVM,
+	"AVAXC":     FormatEVM,
+	"TRON":      FormatTron,
+	"TRC20":     FormatTron,
+	"SOLANA":    FormatSolana,
+	"SOL":       FormatSolana,
+	"SPL":       FormatSolana,
+	"STELLAR":   FormatStellar,
+	"XLM":       FormatStellar,
+}
+
+// AddressValidator reports whether address is well formed for a network.
+type AddressValidator func(address string) error
+
+var addressValidators = map[Format]AddressValidator{
+	FormatEVM:     ValidateEVMAddress,
+	FormatTron:    ValidateTronAddress,
+	FormatSolana:  ValidateSolanaAddress,
+	FormatStellar: ValidateStellarAddress,
+}
+
+// FormatForNetwork returns the format used by network. Names are matched
+// case-insensitively.
+func FormatForNetwork(network string) (Format, error) {
+	networksMu.RLock()
+	format, ok := networkFormats[strings.ToUpper(strings.TrimSpace(network))]
+	networksMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownNetwork, network)
+	}
+	return format, nil
+}
+
+// RegisterNetwork makes network known under format, for networks added to
+// Cashramp after this package was released.
+func RegisterNetwork(network string, format Format) {
+	networksMu.Lock()
+	defer networksMu.Unlock()
+	networkFormats[strings.ToUpper(strings.TrimSpace(network))] = format
+}
+
+// AddressValidatorFor returns the address validator for network.
+func AddressValidatorFor(network string) (AddressValidator, error) {
+	format, err := FormatForNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return addressValidators[format], nil
+}
+
+// ValidateAddress reports whether address is well formed for network.
+func ValidateAddress(network, address string) error {
+	validate, err := AddressValidatorFor(network)
+	if err != nil {
+		return err
+	}
+	if err := validate(address); err != nil {
+		return fmt.Errorf("%s: %w", network, err)
+	}
+	return nil
+}
+
+func invalidAddress(address, format string, args ...any) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidAddress, address, fmt.Sprintf(format, args...))
+}
+
+// ValidateEVMAddress checks for a 0x-prefixed 20 byte hex address. Mixed-case
+// addresses must carry a valid EIP-55 checksum; all lower or upper case
+// addresses carry none and are accepted.
+func ValidateEVMAddress(address string) error {
+	if !strings.HasPrefix(address, "0x") || len(address) != 42 {
+		return invalidAddress(address, "expected 0x followed by 40 hex characters")
+	}
+	digits := address[2:]
+	if _, err := hex.DecodeString(digits); err != nil {
+		return invalidAddress(address, "expected 0x followed by 40 hex characters")
+	}
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+	if checksummed := EVMChecksumAddress(address); checksummed != address {
+		return invalidAddress(address, "EIP-55 checksum mismatch, expected %s", checksummed)
+	}
+	return nil
+}
+
+// EVMChecksumAddress returns address with EIP-55 checksum casing applied.
+func EVMChecksumAddress(address string) string {
+	digits := strings.ToLower(strings.TrimPrefix(address, "0x"))
+	hash := keccak256([]byte(digits))
+
+	checksummed := []byte(digits)
+	for i, c := range checksummed {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
+
+// ValidateTronAddress checks for a base58check encoded mainnet address: 21
+// bytes starting with 0x41, which always renders with a leading T.
+func ValidateTronAddress(address string) error {
+	if !strings.HasPrefix(address, "T") || len(address) != 34 {
+		return invalidAddress(address, "expected a 34 character base58check address starting with T")
+	}
+	payload, err := base58CheckDecode(address)
+	if err != nil {
+		return invalidAddress(address, "%s", err)
+	}
+	if len(payload) != 21 || payload[0] != 0x41 {
+		return invalidAddress(address, "expected a 21 byte payload with prefix 0x41")
+	}
+	return nil
+}
+
+// ValidateSolanaAddress checks for a base58 encoded 32 byte public key.
+func ValidateSolanaAddress(address string) error {
+	if len(address) < 32 || len(address) > 44 {
+		return invalidAddress(address, "expected 32 to 44 base58 characters")
+	}
+	decoded, err := base58Decode(address)
+	if err != nil {
+		return invalidAddress(address, "%s", err)
+	}
+	if len(decoded) != 32 {
+		return invalidAddress(address, "expected a 32 byte public key, got %d bytes", len(decoded))
+	}
+	return nil
+}
+
+// ValidateStellarAddress checks for a StrKey encoded account ID: base32 of a
+// version byte, 32 byte key and CRC16-XModem checksum, starting with G.
+func ValidateStellarAddress(address string) error {
+	if !strings.HasPrefix(address, "G") || len(address) != 56 {
+		return invalidAddress(address, "expected a 56 character account ID starting with G")
+	}
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(address)
+	if err != nil || len(decoded) != 35 {
+		return invalidAddress(address, "expected base32 encoded account ID")
+	}
+	payload, checksum := decoded[:33], binary.LittleEndian.Uint16(decoded[33:])
+	if payload[0] != 6<<3 {
+		return invalidAddress(address, "unexpected version byte")
+	}
+	if crc16XModem(payload) != checksum {
+		return invalidAddress(address, "checksum mismatch")
+	}
+	return nil
+}
+
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
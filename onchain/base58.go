@@ -0,0 +1,65 @@
+package onchain
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Index = func() [256]int {
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		index[base58Alphabet[i]] = i
+	}
+	return index
+}()
+
+func base58Decode(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty base58 string")
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		digit := base58Index[s[i]]
+		if digit < 0 {
+			return nil, errors.New("invalid base58 character " + string(s[i]))
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	leadingZeros := 0
+	for leadingZeros < len(s) && s[leadingZeros] == '1' {
+		leadingZeros++
+	}
+	return append(make([]byte, leadingZeros), n.Bytes()...), nil
+}
+
+// base58CheckDecode decodes s and verifies its trailing four byte double
+// SHA-256 checksum, returning the payload without the checksum.
+func base58CheckDecode(s string) ([]byte, error) {
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < 5 {
+		return nil, errors.New("too short for a checksum")
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	for i := range checksum {
+		if checksum[i] != second[i] {
+			return nil, errors.New("checksum mismatch")
+		}
+	}
+	return payload, nil
+}
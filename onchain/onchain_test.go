@@ -0,0 +1,102 @@
+package onchain
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeccak256(t *testing.T) {
+	empty := keccak256(nil)
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(empty[:]))
+
+	abc := keccak256([]byte("abc"))
+	assert.Equal(t, "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45", hex.EncodeToString(abc[:]))
+
+	// Inputs at and beyond the 136-byte rate exercise padding into a new block
+	// and absorbing more than one block.
+	exactRate := keccak256([]byte(strings.Repeat("a", 136)))
+	assert.Equal(t, "a6c4d403279fe3e0af03729caada8374b5ca54d8065329a3ebcaeb4b60aa386e", hex.EncodeToString(exactRate[:]))
+
+	zeros := keccak256(make([]byte, 200))
+	assert.Equal(t, "e1bb54e1bc3af48d01e5dbfc81015c98152a574f6428c6948aa4837c9c0baad9", hex.EncodeToString(zeros[:]))
+
+	sequence := make([]byte, 300)
+	for i := range sequence {
+		sequence[i] = byte(i)
+	}
+	long := keccak256(sequence)
+	assert.Equal(t, "a679e749a6af300c36e7ff2255d220864eab27b382f9cfdc5aa4d13563ba36ff", hex.EncodeToString(long[:]))
+}
+
+func TestValidateAddress(t *testing.T) {
+	valid := map[string]string{
+		"ERC20":   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"celo":    "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+		"TRC20":   "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		"SOLANA":  "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		"STELLAR": "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+	}
+	for network, address := range valid {
+		assert.NoError(t, ValidateAddress(network, address), network)
+	}
+
+	invalid := map[string]string{
+		"ERC20":   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+		"BEP20":   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+		"TRC20":   "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u",
+		"SOLANA":  "0OIl",
+		"STELLAR": "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN8",
+	}
+	for network, address := range invalid {
+		assert.ErrorIs(t, ValidateAddress(network, address), ErrInvalidAddress, network)
+	}
+
+	assert.ErrorIs(t, ValidateAddress("DOGE", "D123"), ErrUnknownNetwork)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", EVMChecksumAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+}
+
+func TestValidateTransactionHash(t *testing.T) {
+	valid := map[string]string{
+		"POLYGON": "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+		"TRC20":   "e3c52a6c6f2f0d3c6f5b8e5a7b1ecf6d8f1a0f2a4d25b8c83c4bd1f1f3a1c9d2",
+		"SOLANA":  "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"STELLAR": "b9d0b2292c4e09e8eb22d036171491e87b8d2086bf8b265874c8d182cb9c9020",
+	}
+	for network, hash := range valid {
+		assert.NoError(t, ValidateTransactionHash(network, hash), network)
+	}
+
+	err := ValidateTransactionHash("CELO", "88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b")
+	assert.ErrorIs(t, err, ErrInvalidTransactionHash)
+	assert.ErrorContains(t, err, "0x followed by 64 hex characters")
+
+	assert.ErrorIs(t, ValidateTransactionHash("TRC20", "0x"+valid["TRC20"]), ErrInvalidTransactionHash)
+	assert.ErrorIs(t, ValidateTransactionHash("SOLANA", valid["SOLANA"][:40]), ErrInvalidTransactionHash)
+}
+
+func TestValidateSolanaSignatureLength(t *testing.T) {
+	valid := []string{
+		// 64 bytes of 0xff, the longest encoding.
+		"67rpwLCuS5DGA8KGZXKsVQ7dnPb9goRLoKfgGbLfQg9WoLUgNY77E2jT11fem3coV9nAkguBACzrU1iyZM4B8roQ",
+		// A leading zero byte, encoded in 86 characters.
+		"1GEoSr1zQmSdVRBytTGqwokiyaVTd1mNJ2jkEGmhDSmTwX9CbcVZrrYJZc42r5Wyu9rAJGFSDAPoAHh5rsMt8q",
+		// 64 zero bytes, the shortest encoding.
+		strings.Repeat("1", 64),
+	}
+	for _, hash := range valid {
+		assert.NoError(t, ValidateSolanaSignature(hash), "%d characters", len(hash))
+	}
+
+	for _, hash := range []string{
+		strings.Repeat("1", 63),
+		valid[0] + "1",
+		strings.Repeat("z", 88),
+	} {
+		err := ValidateSolanaSignature(hash)
+		assert.ErrorIs(t, err, ErrInvalidTransactionHash, "%d characters", len(hash))
+		assert.ErrorContains(t, err, "64 to 88 characters")
+	}
+}
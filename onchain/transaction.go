@@ -0,0 +1,94 @@
+package onchain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidTransactionHash = errors.New("invalid transaction hash")
+
+// TransactionHashValidator reports whether hash is a well formed transaction
+// identifier for a network.
+type TransactionHashValidator func(hash string) error
+
+var transactionHashValidators = map[Format]TransactionHashValidator{
+	FormatEVM:     ValidateEVMTransactionHash,
+	FormatTron:    ValidateTronTransactionHash,
+	FormatSolana:  ValidateSolanaSignature,
+	FormatStellar: ValidateStellarTransactionHash,
+}
+
+// TransactionHashValidatorFor returns the transaction hash validator for
+// network.
+func TransactionHashValidatorFor(network string) (TransactionHashValidator, error) {
+	format, err := FormatForNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return transactionHashValidators[format], nil
+}
+
+// ValidateTransactionHash reports whether hash is well formed for network.
+func ValidateTransactionHash(network, hash string) error {
+	validate, err := TransactionHashValidatorFor(network)
+	if err != nil {
+		return err
+	}
+	if err := validate(hash); err != nil {
+		return fmt.Errorf("%s: %w", network, err)
+	}
+	return nil
+}
+
+func invalidTransactionHash(hash, expected string) error {
+	return fmt.Errorf("%w %q: expected %s", ErrInvalidTransactionHash, hash, expected)
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// ValidateEVMTransactionHash checks for a 0x-prefixed 32 byte hex hash.
+func ValidateEVMTransactionHash(hash string) error {
+	if !strings.HasPrefix(hash, "0x") || !isHex(hash[2:], 64) {
+		return invalidTransactionHash(hash, "0x followed by 64 hex characters")
+	}
+	return nil
+}
+
+// ValidateTronTransactionHash checks for an unprefixed 32 byte hex hash.
+func ValidateTronTransactionHash(hash string) error {
+	if !isHex(hash, 64) {
+		return invalidTransactionHash(hash, "64 hex characters without a 0x prefix")
+	}
+	return nil
+}
+
+// ValidateSolanaSignature checks for a base58 encoded 64 byte transaction
+// signature. Most signatures are 87 or 88 characters, but leading zero bytes
+// encode shorter, down to 64 characters.
+func ValidateSolanaSignature(hash string) error {
+	const expected = "a base58 encoded 64 byte signature (64 to 88 characters)"
+	if len(hash) < 64 || len(hash) > 88 {
+		return invalidTransactionHash(hash, expected)
+	}
+	decoded, err := base58Decode(hash)
+	if err != nil || len(decoded) != 64 {
+		return invalidTransactionHash(hash, expected)
+	}
+	return nil
+}
+
+// ValidateStellarTransactionHash checks for an unprefixed 32 byte hex hash.
+func ValidateStellarTransactionHash(hash string) error {
+	if !isHex(hash, 64) {
+		return invalidTransactionHash(hash, "64 hex characters")
+	}
+	return nil
+}
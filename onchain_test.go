@@ -0,0 +1,56 @@
+package cashrampsdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/onchain"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithdrawOnchainAddressValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("rejected withdrawals should not reach the API")
+	}))
+	defer server.Close()
+
+	client := dummyClient(t, server)
+	input := types.WithdrawOnchainInput{Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", Amount: "10"}
+
+	_, err := client.WithdrawOnchain(input, cashrampsdk.WithAddressNetwork("TRC20"))
+	assert.ErrorIs(t, err, onchain.ErrInvalidAddress)
+
+	usdt := types.RampableAssets{Symbol: "USDT", Networks: []string{"TRC20", "CELO"}}
+	_, err = client.WithdrawOnchain(input, cashrampsdk.WithAddressAsset(usdt, "BASE"))
+	assert.ErrorContains(t, err, "USDT is not available on BASE")
+}
+
+func TestWithdrawOnchainValidAddress(t *testing.T) {
+	responseBytes := createMockGraphQLResponse(t, "withdrawOnchain", map[string]any{"id": "wd_1", "status": "created"})
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+	usdt := types.RampableAssets{Symbol: "USDT", Networks: []string{"TRC20", "CELO"}}
+	input := types.WithdrawOnchainInput{Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", Amount: "10"}
+
+	withdrawal, err := client.WithdrawOnchain(input, cashrampsdk.WithAddressAsset(usdt, "celo"))
+	assert.NoError(t, err)
+	assert.Equal(t, "wd_1", withdrawal.ID)
+}
+
+func TestConfirmTransactionHashValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("rejected confirmations should not reach the API")
+	}))
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	_, err := client.ConfirmTransaction(types.ConfirmTransactionInput{PaymentRequest: "1", TransactionHash: "tx_hash"}, cashrampsdk.WithTransactionNetwork("TRC20"))
+	assert.ErrorIs(t, err, onchain.ErrInvalidTransactionHash)
+	assert.ErrorContains(t, err, "expected 64 hex characters")
+}
@@ -0,0 +1,41 @@
+package cashrampsdk
+
+import (
+	"sync"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+)
+
+// parsedDocuments caches the built-in documents parsed by parseDocument.
+// Other documents are parsed on every call, since callers may build them
+// dynamically and caching them would grow without bound.
+var parsedDocuments sync.Map
+
+func parseDocument(document string) (*graphql.Document, error) {
+	if cached, ok := parsedDocuments.Load(document); ok {
+		return cached.(*graphql.Document), nil
+	}
+	doc, err := graphql.Parse(document)
+	if err != nil {
+		return nil, err
+	}
+	if _, builtIn := persistedQueryHashes[document]; builtIn {
+		parsedDocuments.Store(document, doc)
+	}
+	return doc, nil
+}
+
+// resolveOperationName returns the operationName to send with document: the
+// requested one, which must exist, or the name of its only operation, which
+// is empty if that operation is anonymous.
+func resolveOperationName(document, requested string) (string, error) {
+	doc, err := parseDocument(document)
+	if err != nil {
+		return "", err
+	}
+	op, err := doc.Operation(requested)
+	if err != nil {
+		return "", err
+	}
+	return op.Name, nil
+}
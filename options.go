@@ -0,0 +1,81 @@
+package cashrampsdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/limits"
+)
+
+// ClientOption configures optional Client behaviour in InitialiseClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used for API requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithPaymentMethodValidation makes AddPaymentMethod check the supplied fields
+// against the payment method type's schema before sending. Schemas are
+// fetched with GetPaymentMethodTypes on first use and cached on the client.
+func WithPaymentMethodValidation() ClientOption {
+	return func(c *Client) {
+		c.paymentMethodTypes = newPaymentMethodTypeCache()
+	}
+}
+
+// WithContractCheck makes InitialiseClient fail if any built-in query or
+// mutation declares variables its input type does not send, or vice versa.
+func WithContractCheck() ClientOption {
+	return func(c *Client) {
+		c.checkContracts = true
+	}
+}
+
+// WithPersistedQueries enables automatic persisted queries: requests send
+// the SHA-256 hash of their document instead of its text, and the full
+// document only when the server has not cached it yet. If the server does
+// not support persisted queries the client falls back to full documents.
+func WithPersistedQueries() ClientOption {
+	return func(c *Client) {
+		c.persistedQueries = &persistedQueries{}
+	}
+}
+
+// WithLimitsGuard makes InitiateHostedPayment and WithdrawOnchain check
+// amounts against the account's ramp limits before sending, reserving them in
+// store towards the daily limit in the same step so concurrent requests
+// cannot exceed it. Reservations are released if the API rejects the
+// request with a GraphQL error or 4xx status. Limits are fetched with GetRampLimits and cached for five minutes.
+func WithLimitsGuard(store limits.Store) ClientOption {
+	return func(c *Client) {
+		c.limits = limits.NewGuard(store, c.GetRampLimits, 5*time.Minute)
+	}
+}
+
+// RequestOption configures a single SendRequest call.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	operationName string
+	ctx           context.Context
+}
+
+// WithOperationName selects the operation to run from a document that
+// contains several, and is sent to the server as operationName.
+func WithOperationName(name string) RequestOption {
+	return func(o *requestOptions) {
+		o.operationName = name
+	}
+}
+
+// WithContext makes the HTTP request carry ctx, so it is abandoned when ctx
+// is cancelled or its deadline passes.
+func WithContext(ctx context.Context) RequestOption {
+	return func(o *requestOptions) {
+		o.ctx = ctx
+	}
+}
@@ -0,0 +1,91 @@
+package cashrampsdk
+
+import (
+	"iter"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// DefaultPageSize is the number of items list methods request per page.
+const DefaultPageSize = 50
+
+// PageFetcher fetches the page of a list that follows cursor, or the first
+// page when cursor is empty.
+type PageFetcher[T any] func(cursor string) ([]T, types.PageInfo, error)
+
+// Paginate iterates over every item of a cursor-paginated list, fetching
+// pages as they are needed. A failed fetch is yielded once as an error and
+// ends the iteration; stopping early fetches no further pages.
+func Paginate[T any](fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			items, pageInfo, err := fetch(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !pageInfo.HasNextPage || pageInfo.EndCursor == "" || pageInfo.EndCursor == cursor {
+				return
+			}
+			cursor = pageInfo.EndCursor
+		}
+	}
+}
+
+// ListOption configures a list method.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	pageSize int
+}
+
+// WithPageSize sets how many items are requested per page. Sizes below 1 are
+// not an error: they are replaced with DefaultPageSize, as if the option had
+// not been given.
+func WithPageSize(size int) ListOption {
+	return func(o *listOptions) {
+		if size < 1 {
+			size = DefaultPageSize
+		}
+		o.pageSize = size
+	}
+}
+
+func newListOptions(opts []ListOption) *listOptions {
+	options := &listOptions{pageSize: DefaultPageSize}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// ListPaymentRequests iterates over the payment requests matching filter:
+//
+//	for paymentRequest, err := range client.ListPaymentRequests(filter) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) ListPaymentRequests(filter types.PaymentRequestFilter, opts ...ListOption) iter.Seq2[types.PaymentRequest, error] {
+	options := newListOptions(opts)
+	return Paginate(func(cursor string) ([]types.PaymentRequest, types.PageInfo, error) {
+		if cursor == "" {
+			if err := filter.Validate(); err != nil {
+				return nil, types.PageInfo{}, err
+			}
+		}
+		page, err := c.doMerchantPaymentRequests(paymentRequestsVariables{Filter: filter, First: options.pageSize, After: cursor})
+		if err != nil {
+			return nil, types.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+}
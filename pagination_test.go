@@ -0,0 +1,159 @@
+package cashrampsdk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPaymentRequestPages serves pages of payment requests with references
+// order_1 to order_<total>, using each page's last reference as its cursor.
+func mockPaymentRequestPages(t *testing.T, total int) (*httptest.Server, *atomic.Int32) {
+	fetches := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		var body struct {
+			OperationName string `json:"operationName"`
+			Variables     struct {
+				Filter map[string]any `json:"filter"`
+				First  int            `json:"first"`
+				After  *string        `json:"after"`
+			} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "PaymentRequests", body.OperationName)
+		assert.Equal(t, map[string]any{"status": "completed"}, body.Variables.Filter)
+
+		start := 0
+		if body.Variables.After != nil {
+			fmt.Sscanf(*body.Variables.After, "order_%d", &start)
+		}
+		end := min(start+body.Variables.First, total)
+		nodes := []map[string]any{}
+		for i := start + 1; i <= end; i++ {
+			nodes = append(nodes, map[string]any{"reference": fmt.Sprintf("order_%d", i), "status": "completed"})
+		}
+		w.Write(createMockGraphQLResponse(t, "merchantPaymentRequests", map[string]any{
+			"nodes":    nodes,
+			"pageInfo": map[string]any{"hasNextPage": end < total, "endCursor": fmt.Sprintf("order_%d", end)},
+		}))
+	}))
+	return server, fetches
+}
+
+func TestListPaymentRequests(t *testing.T) {
+	server, fetches := mockPaymentRequestPages(t, 7)
+	defer server.Close()
+
+	var references []string
+	filter := types.PaymentRequestFilter{Status: types.PaymentStatusCompleted}
+	for paymentRequest, err := range dummyClient(t, server).ListPaymentRequests(filter, cashrampsdk.WithPageSize(3)) {
+		assert.NoError(t, err)
+		references = append(references, paymentRequest.Reference)
+	}
+	assert.Equal(t, []string{"order_1", "order_2", "order_3", "order_4", "order_5", "order_6", "order_7"}, references)
+	assert.Equal(t, int32(3), fetches.Load())
+}
+
+func TestListPaymentRequestsStopsEarly(t *testing.T) {
+	server, fetches := mockPaymentRequestPages(t, 100)
+	defer server.Close()
+
+	count := 0
+	filter := types.PaymentRequestFilter{Status: types.PaymentStatusCompleted}
+	for _, err := range dummyClient(t, server).ListPaymentRequests(filter, cashrampsdk.WithPageSize(10)) {
+		assert.NoError(t, err)
+		if count++; count == 15 {
+			break
+		}
+	}
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestListPaymentRequestsInvalidPageSize(t *testing.T) {
+	server, fetches := mockPaymentRequestPages(t, 2*cashrampsdk.DefaultPageSize)
+	defer server.Close()
+
+	filter := types.PaymentRequestFilter{Status: types.PaymentStatusCompleted}
+	for _, size := range []int{0, -5} {
+		fetches.Store(0)
+		count := 0
+		for _, err := range dummyClient(t, server).ListPaymentRequests(filter, cashrampsdk.WithPageSize(size)) {
+			assert.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 2*cashrampsdk.DefaultPageSize, count)
+		assert.Equal(t, int32(2), fetches.Load(), "page size %d", size)
+	}
+}
+
+func TestListPaymentRequestsPageSizeFallback(t *testing.T) {
+	emptyPage := createMockGraphQLResponse(t, "merchantPaymentRequests", map[string]any{
+		"nodes":    []any{},
+		"pageInfo": map[string]any{"hasNextPage": false},
+	})
+	for _, size := range []int{0, -5} {
+		server := mockGraphQLServer(t, emptyPage, http.StatusOK, true, fmt.Sprintf(`"first":%d`, cashrampsdk.DefaultPageSize))
+		for _, err := range dummyClient(t, server).ListPaymentRequests(types.PaymentRequestFilter{}, cashrampsdk.WithPageSize(size)) {
+			assert.NoError(t, err, "page size %d", size)
+		}
+		server.Close()
+	}
+}
+
+func TestListPaymentRequestsErrors(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "merchantPaymentRequests", nil, "not authorised"), http.StatusOK, true)
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	var errs []error
+	for _, err := range client.ListPaymentRequests(types.PaymentRequestFilter{}) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "not authorised")
+	}
+
+	for _, err := range client.ListPaymentRequests(types.PaymentRequestFilter{PaymentType: "swap"}) {
+		var validationErr *types.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "b": {3}, "c": {}}
+	next := map[string]string{"": "b", "b": "c"}
+	fetch := func(cursor string) ([]int, types.PageInfo, error) {
+		return pages[cursor], types.PageInfo{HasNextPage: next[cursor] != "", EndCursor: next[cursor]}, nil
+	}
+
+	var items []int
+	for item, err := range cashrampsdk.Paginate(fetch) {
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	// A server repeating its cursor must not loop forever.
+	repeating := func(cursor string) ([]int, types.PageInfo, error) {
+		return []int{1}, types.PageInfo{HasNextPage: true, EndCursor: "same"}, nil
+	}
+	items = nil
+	for item := range cashrampsdk.Paginate(repeating) {
+		items = append(items, item)
+	}
+	assert.Equal(t, []int{1, 1}, items)
+
+	failed := errors.New("boom")
+	for _, err := range cashrampsdk.Paginate(func(string) ([]int, types.PageInfo, error) { return nil, types.PageInfo{}, failed }) {
+		assert.ErrorIs(t, err, failed)
+	}
+}
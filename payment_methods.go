@@ -0,0 +1,154 @@
+package cashrampsdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// ErrPaymentMethodNotFound is returned when no saved payment method has the
+// given ID.
+var ErrPaymentMethodNotFound = errors.New("payment method not found")
+
+// ListPaymentMethods returns the payment methods saved for a customer.
+func (c *Client) ListPaymentMethods(customerID string) ([]types.PaymentMethod, error) {
+	if err := requireField("customer", customerID); err != nil {
+		return nil, err
+	}
+
+	return c.doP2pPaymentMethods(paymentMethodsVariables{CustomerID: customerID})
+}
+
+// GetPaymentMethod fetches a saved payment method, including the definition
+// of its payment method type. It returns an error wrapping
+// ErrPaymentMethodNotFound if there is no such payment method.
+func (c *Client) GetPaymentMethod(paymentMethodID string) (*types.PaymentMethod, error) {
+	if err := requireField("id", paymentMethodID); err != nil {
+		return nil, err
+	}
+
+	paymentMethod, err := c.doP2pPaymentMethod(paymentMethodVariables{ID: paymentMethodID})
+	if err != nil {
+		return nil, err
+	}
+	if paymentMethod.ID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentMethodNotFound, paymentMethodID)
+	}
+	return paymentMethod, nil
+}
+
+// RemovePaymentMethod deletes a customer's saved payment method.
+func (c *Client) RemovePaymentMethod(paymentMethod types.RemovePaymentMethodInput) (bool, error) {
+	if err := paymentMethod.Validate(); err != nil {
+		return false, err
+	}
+
+	return c.doRemovePaymentMethod(paymentMethod)
+}
+
+// How long payment method type schemas, and IDs found not to exist, are
+// trusted before the schemas are loaded again.
+const (
+	paymentMethodTypeTTL     = 10 * time.Minute
+	paymentMethodTypeMissTTL = time.Minute
+)
+
+// paymentMethodTypeCache holds payment method type schemas keyed by ID.
+// Payment method types are only queryable per country, so a load fetches the
+// types for every available country. Concurrent misses share one load.
+type paymentMethodTypeCache struct {
+	mu       sync.Mutex
+	types    map[string]types.PaymentMethodTypes
+	loadedAt time.Time
+	// misses records when IDs were last found not to exist.
+	misses map[string]time.Time
+	// loading is closed when the load in progress, if any, finishes.
+	loading chan struct{}
+}
+
+func newPaymentMethodTypeCache() *paymentMethodTypeCache {
+	return &paymentMethodTypeCache{types: map[string]types.PaymentMethodTypes{}, misses: map[string]time.Time{}}
+}
+
+func (p *paymentMethodTypeCache) get(c *Client, id string) (types.PaymentMethodTypes, error) {
+	for {
+		p.mu.Lock()
+		now := time.Now()
+		if definition, ok := p.types[id]; ok && now.Sub(p.loadedAt) < paymentMethodTypeTTL {
+			p.mu.Unlock()
+			return definition, nil
+		}
+		if missedAt, ok := p.misses[id]; ok && now.Sub(missedAt) < paymentMethodTypeMissTTL {
+			p.mu.Unlock()
+			return types.PaymentMethodTypes{}, fmt.Errorf("unknown payment method type %q", id)
+		}
+		if loading := p.loading; loading != nil {
+			p.mu.Unlock()
+			<-loading
+			continue
+		}
+		loading := make(chan struct{})
+		p.loading = loading
+		p.mu.Unlock()
+
+		loaded, err := loadPaymentMethodTypes(c)
+
+		p.mu.Lock()
+		p.loading = nil
+		close(loading)
+		if err != nil {
+			p.mu.Unlock()
+			return types.PaymentMethodTypes{}, err
+		}
+		p.types = loaded
+		p.loadedAt = time.Now()
+		for missed, missedAt := range p.misses {
+			if p.loadedAt.Sub(missedAt) >= paymentMethodTypeMissTTL {
+				delete(p.misses, missed)
+			}
+		}
+		definition, ok := loaded[id]
+		if !ok {
+			p.misses[id] = p.loadedAt
+		}
+		p.mu.Unlock()
+
+		if !ok {
+			return types.PaymentMethodTypes{}, fmt.Errorf("unknown payment method type %q", id)
+		}
+		return definition, nil
+	}
+}
+
+func loadPaymentMethodTypes(c *Client) (map[string]types.PaymentMethodTypes, error) {
+	countries, err := c.GetAvailableCountries()
+	if err != nil {
+		return nil, err
+	}
+	loaded := map[string]types.PaymentMethodTypes{}
+	for _, country := range countries {
+		paymentMethodTypes, err := c.GetPaymentMethodTypes(country.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, definition := range paymentMethodTypes {
+			loaded[definition.ID] = definition
+		}
+	}
+	return loaded, nil
+}
+
+func (c *Client) validatePaymentMethodFields(payment types.AddPaymentMethodInput) error {
+	if c.paymentMethodTypes == nil {
+		return nil
+	}
+
+	definition, err := c.paymentMethodTypes.get(c, payment.PaymentMethodTypeID)
+	if err != nil {
+		return err
+	}
+	return types.ValidatePaymentMethodFields(definition, payment)
+}
@@ -0,0 +1,168 @@
+package cashrampsdk_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPaymentMethodSchemaValidation(t *testing.T) {
+	server := mockGraphQLRouter(t, map[string]any{
+		"availableCountries": []map[string]string{{"id": "ng", "name": "Nigeria", "code": "NG"}},
+		"p2pPaymentMethodTypes": []map[string]any{
+			{"id": "bank", "identifier": "ng_bank", "label": "Bank Transfer", "fields": []map[string]any{
+				{"label": "Account Number", "identifier": "account_number", "required": true},
+				{"label": "Bank Name", "identifier": "bank_name", "required": true},
+			}},
+		},
+		"addPaymentMethod": map[string]any{"id": "pm_1", "value": "0123456789"},
+	})
+	defer server.Close()
+
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithPaymentMethodValidation())
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	input := types.NewAddPaymentMethodInput("cus_1", "bank").
+		WithField("account_number", "0123456789").
+		WithField("branch", "Lekki")
+
+	_, err = client.AddPaymentMethod(input)
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), `unknown identifier "branch"`)
+	assert.Contains(t, err.Error(), `missing required field "bank_name"`)
+
+	input = types.NewAddPaymentMethodInput("cus_1", "bank").WithFields(map[string]string{
+		"account_number": "0123456789",
+		"bank_name":      "Access Bank",
+	})
+	paymentMethod, err := client.AddPaymentMethod(input)
+	assert.NoError(t, err)
+	assert.Equal(t, "pm_1", paymentMethod.ID)
+}
+
+func TestAddPaymentMethodSchemaCache(t *testing.T) {
+	var loads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		switch {
+		case strings.Contains(string(body), "availableCountries"):
+			loads.Add(1)
+			time.Sleep(20 * time.Millisecond) // long enough for concurrent misses to overlap
+			w.Write(createMockGraphQLResponse(t, "availableCountries", []map[string]string{{"id": "ng", "name": "Nigeria", "code": "NG"}}))
+		case strings.Contains(string(body), "p2pPaymentMethodTypes"):
+			w.Write(createMockGraphQLResponse(t, "p2pPaymentMethodTypes", []map[string]any{{"id": "bank", "identifier": "ng_bank", "label": "Bank Transfer", "fields": []map[string]any{
+				{"label": "Account Number", "identifier": "account_number", "required": true},
+			}}}))
+		default:
+			w.Write(createMockGraphQLResponse(t, "addPaymentMethod", map[string]any{"id": "pm_1", "value": "0123456789"}))
+		}
+	}))
+	defer server.Close()
+
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithPaymentMethodValidation())
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.AddPaymentMethod(types.NewAddPaymentMethodInput("cus_1", "bnak").WithField("account_number", "0123456789"))
+			assert.ErrorContains(t, err, `unknown payment method type "bnak"`)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load(), "concurrent misses should share one load")
+
+	// The miss is remembered, and known types come from the same load.
+	_, err = client.AddPaymentMethod(types.NewAddPaymentMethodInput("cus_1", "bnak").WithField("account_number", "0123456789"))
+	assert.Error(t, err)
+	_, err = client.AddPaymentMethod(types.NewAddPaymentMethodInput("cus_1", "bank").WithField("account_number", "0123456789"))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+var testPaymentMethod = map[string]any{
+	"id":    "pm_1",
+	"value": "0123456789",
+	"fields": []map[string]string{
+		{"identifier": "bank_name", "value": "Access Bank"},
+		{"identifier": "account_number", "value": "0123456789"},
+		{"identifier": "branch", "value": "Lekki"},
+	},
+	"p2pPaymentMethodType": map[string]any{"id": "bank", "identifier": "ng_bank", "label": "Bank Transfer", "fields": []map[string]any{
+		{"label": "Account Number", "identifier": "account_number", "required": true},
+		{"label": "Bank Name", "identifier": "bank_name", "required": true},
+	}},
+}
+
+func TestListPaymentMethods(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "p2pPaymentMethods", []any{testPaymentMethod}), http.StatusOK, true,
+		`"operationName":"PaymentMethods"`, `"customer":"cus_1"`)
+	defer server.Close()
+
+	paymentMethods, err := dummyClient(t, server).ListPaymentMethods("cus_1")
+	assert.NoError(t, err)
+	if assert.Len(t, paymentMethods, 1) {
+		paymentMethod := paymentMethods[0]
+		assert.Equal(t, "ng_bank", paymentMethod.PaymentMethodType.Identifier)
+		assert.Equal(t, []types.LabelledFieldValue{
+			{PaymentMethodField: types.PaymentMethodField{Label: "Account Number", Identifier: "account_number", Required: true}, Value: "0123456789"},
+			{PaymentMethodField: types.PaymentMethodField{Label: "Bank Name", Identifier: "bank_name", Required: true}, Value: "Access Bank"},
+			{PaymentMethodField: types.PaymentMethodField{Label: "branch", Identifier: "branch"}, Value: "Lekki"},
+		}, paymentMethod.LabelledFields())
+	}
+
+	_, err = dummyClient(t, server).ListPaymentMethods(" ")
+	var validationErr *types.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		_, ok := validationErr.Field("customer")
+		assert.True(t, ok)
+	}
+}
+
+func TestGetPaymentMethod(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "p2pPaymentMethod", testPaymentMethod), http.StatusOK, true, `"id":"pm_1"`)
+	defer server.Close()
+
+	paymentMethod, err := dummyClient(t, server).GetPaymentMethod("pm_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "pm_1", paymentMethod.ID)
+	assert.Equal(t, "bank", paymentMethod.PaymentMethodType.ID)
+	bankName, ok := paymentMethod.FieldValue("bank_name")
+	assert.True(t, ok)
+	assert.Equal(t, "Access Bank", bankName)
+
+	notFound := mockGraphQLServer(t, []byte(`{"data":{"p2pPaymentMethod":null}}`), http.StatusOK, true)
+	defer notFound.Close()
+	_, err = dummyClient(t, notFound).GetPaymentMethod("pm_404")
+	assert.ErrorIs(t, err, cashrampsdk.ErrPaymentMethodNotFound)
+}
+
+func TestRemovePaymentMethod(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "removePaymentMethod", true), http.StatusOK, true,
+		`"operationName":"RemovePaymentMethod"`, `"paymentMethod":"pm_1"`)
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	removed, err := client.RemovePaymentMethod(types.RemovePaymentMethodInput{PaymentMethodID: "pm_1"})
+	assert.NoError(t, err)
+	assert.True(t, removed)
+
+	_, err = client.RemovePaymentMethod(types.RemovePaymentMethodInput{})
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,93 @@
+package cashrampsdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Error messages and codes servers use to ask for the full document.
+const (
+	persistedQueryNotFound     = "PersistedQueryNotFound"
+	persistedQueryNotSupported = "PersistedQueryNotSupported"
+)
+
+type reqExtensions struct {
+	PersistedQuery *persistedQueryExtension `json:"persistedQuery,omitempty"`
+}
+
+type persistedQueryExtension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// persistedQueries is the automatic persisted query state of a client.
+type persistedQueries struct {
+	// unsupported is set once the server reports it has no persisted query
+	// support, after which full documents are always sent.
+	unsupported atomic.Bool
+}
+
+// PersistedQueryHash returns the hex SHA-256 hash that identifies document as
+// an automatic persisted query. Hashes of the built-in queries and mutations
+// are precomputed by cashrampgen; other documents are hashed on every call
+// rather than cached, since callers may build them dynamically.
+func PersistedQueryHash(document string) string {
+	if hash, ok := persistedQueryHashes[document]; ok {
+		return hash
+	}
+	sum := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(sum[:])
+}
+
+// roundTrip sends requestBody with query attached. With persisted queries
+// enabled only the query's hash is sent at first, and the full document
+// follows if the server has not seen the hash yet.
+func (c *Client) roundTrip(ctx context.Context, requestBody *reqBody, query string) (*http.Response, []byte, error) {
+	if c.persistedQueries == nil || c.persistedQueries.unsupported.Load() {
+		requestBody.Query = query
+		return c.post(ctx, requestBody)
+	}
+
+	requestBody.Extensions = &reqExtensions{PersistedQuery: &persistedQueryExtension{
+		Version:    1,
+		Sha256Hash: PersistedQueryHash(query),
+	}}
+	resp, body, err := c.post(ctx, requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch persistedQueryError(body) {
+	case persistedQueryNotFound:
+		// Resending the document with its hash registers it.
+	case persistedQueryNotSupported:
+		c.persistedQueries.unsupported.Store(true)
+		requestBody.Extensions = nil
+	default:
+		return resp, body, nil
+	}
+	requestBody.Query = query
+	return c.post(ctx, requestBody)
+}
+
+// persistedQueryError returns persistedQueryNotFound or
+// persistedQueryNotSupported if the response body reports either.
+func persistedQueryError(body []byte) string {
+	var response rawGraphQLResponse
+	if json.Unmarshal(body, &response) != nil {
+		return ""
+	}
+	for _, graphqlErr := range response.Errors {
+		switch {
+		case graphqlErr.Message == persistedQueryNotFound, graphqlErr.Extensions.Code == "PERSISTED_QUERY_NOT_FOUND":
+			return persistedQueryNotFound
+		case graphqlErr.Message == persistedQueryNotSupported, graphqlErr.Extensions.Code == "PERSISTED_QUERY_NOT_SUPPORTED":
+			return persistedQueryNotSupported
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package cashrampsdk_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/queries"
+	"github.com/stretchr/testify/assert"
+)
+
+type persistedQueryRequest struct {
+	Query      string `json:"query"`
+	Extensions struct {
+		PersistedQuery *struct {
+			Version    int    `json:"version"`
+			Sha256Hash string `json:"sha256Hash"`
+		} `json:"persistedQuery"`
+	} `json:"extensions"`
+}
+
+// mockPersistedQueryServer caches documents by hash like an APQ server and
+// records every request it receives. With supported unset it rejects hashes.
+func mockPersistedQueryServer(t *testing.T, supported bool, requests *[]persistedQueryRequest) *httptest.Server {
+	cache := map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request persistedQueryRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*requests = append(*requests, request)
+
+		if persisted := request.Extensions.PersistedQuery; persisted != nil {
+			if !supported {
+				w.Write(createMockGraphQLResponse(t, "", nil, "PersistedQueryNotSupported"))
+				return
+			}
+			if request.Query == "" && cache[persisted.Sha256Hash] == "" {
+				w.Write(createMockGraphQLResponse(t, "", nil, "PersistedQueryNotFound"))
+				return
+			}
+			cache[persisted.Sha256Hash] = request.Query
+		}
+		w.Write(createMockGraphQLResponse(t, "merchantPaymentRequest", map[string]string{"id": "1", "status": "created"}))
+	}))
+}
+
+func TestPersistedQueries(t *testing.T) {
+	var requests []persistedQueryRequest
+	server := mockPersistedQueryServer(t, true, &requests)
+	defer server.Close()
+
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithPersistedQueries())
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	for range 2 {
+		paymentRequest, err := client.GetPaymentRequest("ref")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", paymentRequest.ID)
+	}
+
+	sum := sha256.Sum256([]byte(queries.PAYMENT_REQUEST))
+	hash := hex.EncodeToString(sum[:])
+	assert.Equal(t, hash, cashrampsdk.PersistedQueryHash(queries.PAYMENT_REQUEST))
+
+	// The first call registers the document; the second sends only its hash.
+	if assert.Len(t, requests, 3) {
+		for _, request := range requests {
+			assert.Equal(t, hash, request.Extensions.PersistedQuery.Sha256Hash)
+		}
+		assert.Empty(t, requests[0].Query)
+		assert.Equal(t, queries.PAYMENT_REQUEST, requests[1].Query)
+		assert.Empty(t, requests[2].Query)
+	}
+}
+
+func TestPersistedQueriesNotSupported(t *testing.T) {
+	var requests []persistedQueryRequest
+	server := mockPersistedQueryServer(t, false, &requests)
+	defer server.Close()
+
+	client, err := cashrampsdk.InitialiseClient("test", "dummy-secret", cashrampsdk.WithPersistedQueries())
+	assert.NoError(t, err)
+	client.ApiUrl = server.URL
+
+	for range 2 {
+		_, err := client.GetPaymentRequest("ref")
+		assert.NoError(t, err)
+	}
+
+	// After the first rejection full documents are sent without hashes.
+	if assert.Len(t, requests, 3) {
+		assert.NotNil(t, requests[0].Extensions.PersistedQuery)
+		for _, request := range requests[1:] {
+			assert.Nil(t, request.Extensions.PersistedQuery)
+			assert.Equal(t, queries.PAYMENT_REQUEST, request.Query)
+		}
+	}
+}
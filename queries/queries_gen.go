@@ -0,0 +1,195 @@
+// Code generated by cashrampgen. DO NOT EDIT.
+
+package queries
+
+const (
+	AVAILABLE_COUNTRIES = `query AvailableCountries {
+  availableCountries {
+    id
+    name
+    code
+  }
+}`
+
+	MARKET_RATE = `query MarketRate($countryCode: String!) {
+  marketRate(countryCode: $countryCode) {
+    depositRate
+    withdrawalRate
+  }
+}`
+
+	PAYMENT_METHOD_TYPES = `query PaymentMethodTypes($country: ID!) {
+  p2pPaymentMethodTypes(country: $country) {
+    id
+    identifier
+    label
+    fields {
+      label
+      identifier
+      required
+    }
+  }
+}`
+
+	RAMPABLE_ASSETS = `query RampableAssets {
+  rampableAssets {
+    name
+    symbol
+    networks
+    contractAddress
+  }
+}`
+
+	RAMP_LIMITS = `query RampLimits {
+  rampLimits {
+    minimumDepositUsd
+    maximumDepositUsd
+    minimumWithdrawalUsd
+    maximumWithdrawalUsd
+    dailyLimitUsd
+  }
+}`
+
+	PAYMENT_REQUEST = `query PaymentRequest($reference: String!) {
+  merchantPaymentRequest(reference: $reference) {
+    id
+    paymentType
+    hostedLink
+    amount
+    currency
+    reference
+    status
+  }
+}`
+
+	PAYMENT_REQUESTS = `query PaymentRequests($filter: MerchantPaymentRequestFilter, $first: Int, $after: String) {
+  merchantPaymentRequests(filter: $filter, first: $first, after: $after) {
+    nodes {
+      id
+      paymentType
+      hostedLink
+      amount
+      currency
+      reference
+      status
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+	CUSTOMER = `query Customer($id: ID!) {
+  customer(id: $id) {
+    id
+    email
+    firstName
+    lastName
+    country {
+      id
+      name
+      code
+    }
+  }
+}`
+
+	CUSTOMERS = `query Customers($email: String, $first: Int, $after: String) {
+  customers(email: $email, first: $first, after: $after) {
+    nodes {
+      id
+      email
+      firstName
+      lastName
+      country {
+        id
+        name
+        code
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+	PAYMENT_METHODS = `query PaymentMethods($customer: ID!) {
+  p2pPaymentMethods(customer: $customer) {
+    id
+    value
+    fields {
+      identifier
+      value
+    }
+    p2pPaymentMethodType {
+      id
+      identifier
+      label
+      fields {
+        label
+        identifier
+        required
+      }
+    }
+  }
+}`
+
+	PAYMENT_METHOD = `query PaymentMethod($id: ID!) {
+  p2pPaymentMethod(id: $id) {
+    id
+    value
+    fields {
+      identifier
+      value
+    }
+    p2pPaymentMethodType {
+      id
+      identifier
+      label
+      fields {
+        label
+        identifier
+        required
+      }
+    }
+  }
+}`
+
+	WITHDRAWAL = `query Withdrawal($id: ID!) {
+  onchainWithdrawal(id: $id) {
+    id
+    status
+    address
+    network
+    transactionHash
+    amountUsd
+    feeUsd
+  }
+}`
+
+	ACCOUNT_LEDGER = `query AccountLedger($filter: LedgerFilter, $first: Int, $after: String) {
+  accountLedger(filter: $filter, first: $first, after: $after) {
+    nodes {
+      id
+      type
+      amount
+      reference
+      description
+      createdAt
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+	ACCOUNT = `query Account {
+  account {
+    id
+    accountBalance
+    depositAddress
+  }
+}`
+)
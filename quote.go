@@ -0,0 +1,79 @@
+package cashrampsdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// DefaultQuoteTTL is how long a quote is honoured when QuoteRequest.TTL is
+// not set.
+const DefaultQuoteTTL = 5 * time.Minute
+
+// QuoteRequest describes a conversion to price. Rounding defaults to
+// types.RoundHalfUp and TTL to DefaultQuoteTTL.
+type QuoteRequest struct {
+	Amount      float64
+	Direction   types.QuoteDirection
+	CountryCode types.CountryCode
+	PaymentType types.PaymentType
+	Rounding    types.RoundingMode
+	TTL         time.Duration
+}
+
+// Quote fetches the market rate for the request's country and converts the
+// amount using the deposit or withdrawal side that matches its payment type,
+// rounded to the target currency's minor units.
+func (c *Client) Quote(request QuoteRequest) (*types.Quote, error) {
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("quote amount must be greater than zero, got %v", request.Amount)
+	}
+	localCurrency, ok := request.CountryCode.Currency()
+	if !ok {
+		return nil, fmt.Errorf("cashramp does not operate in %q", request.CountryCode)
+	}
+
+	var sourceCurrency, targetCurrency types.CurrencyCode
+	switch request.Direction {
+	case types.QuoteUSDToLocal:
+		sourceCurrency, targetCurrency = types.CurrencyCodeUSD, localCurrency
+	case types.QuoteLocalToUSD:
+		sourceCurrency, targetCurrency = localCurrency, types.CurrencyCodeUSD
+	default:
+		return nil, fmt.Errorf("unknown quote direction %q", request.Direction)
+	}
+
+	marketRate, err := c.GetMarketRate(request.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := marketRate.RateFor(request.PaymentType)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := request.TTL
+	if ttl <= 0 {
+		ttl = DefaultQuoteTTL
+	}
+	quotedAt := time.Now()
+
+	quote := &types.Quote{
+		PaymentType:    request.PaymentType,
+		CountryCode:    request.CountryCode,
+		Direction:      request.Direction,
+		SourceAmount:   request.Amount,
+		SourceCurrency: sourceCurrency,
+		TargetCurrency: targetCurrency,
+		Rate:           rate,
+		Rounding:       request.Rounding,
+		QuotedAt:       quotedAt,
+		ExpiresAt:      quotedAt.Add(ttl),
+	}
+	quote.TargetAmount, err = types.ConvertAmount(request.Amount, rate, request.Direction, quote.TargetCurrency, request.Rounding)
+	if err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
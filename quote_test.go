@@ -0,0 +1,108 @@
+package cashrampsdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	responseBytes := createMockGraphQLResponse(t, "marketRate", map[string]float64{
+		"depositRate":    1520.0,
+		"withdrawalRate": 1480.0,
+	})
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, `"countryCode":"NG"`)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	deposit, err := client.Quote(cashrampsdk.QuoteRequest{
+		Amount:      25,
+		Direction:   types.QuoteUSDToLocal,
+		CountryCode: types.CountryCodeNG,
+		PaymentType: types.PaymentTypeDeposit,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1520.0, deposit.Rate)
+	assert.Equal(t, types.CurrencyCodeNGN, deposit.TargetCurrency)
+	assert.Equal(t, 38000.0, deposit.TargetAmount)
+	assert.Equal(t, 38000.0, deposit.LocalAmount())
+	assert.Equal(t, cashrampsdk.DefaultQuoteTTL, deposit.ExpiresAt.Sub(deposit.QuotedAt))
+
+	withdrawal, err := client.Quote(cashrampsdk.QuoteRequest{
+		Amount:      10000,
+		Direction:   types.QuoteLocalToUSD,
+		CountryCode: types.CountryCodeNG,
+		PaymentType: types.PaymentTypeWithdrawal,
+		Rounding:    types.RoundDown,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1480.0, withdrawal.Rate)
+	assert.Equal(t, 6.75, withdrawal.USDAmount())
+
+	_, err = client.Quote(cashrampsdk.QuoteRequest{Amount: 1, Direction: types.QuoteUSDToLocal, CountryCode: "FR", PaymentType: types.PaymentTypeDeposit})
+	assert.ErrorContains(t, err, "does not operate")
+}
+
+func quotedPaymentServer(t *testing.T, depositRate float64) *httptest.Server {
+	return mockGraphQLRouter(t, map[string]any{
+		"marketRate":            map[string]float64{"depositRate": depositRate, "withdrawalRate": depositRate - 40},
+		"initiateHostedPayment": map[string]any{"id": "1", "hostedLink": "https://payment-link.com", "status": "created"},
+	})
+}
+
+func TestInitiateQuotedPayment(t *testing.T) {
+	quote := &types.Quote{
+		PaymentType:    types.PaymentTypeDeposit,
+		CountryCode:    types.CountryCodeNG,
+		Direction:      types.QuoteUSDToLocal,
+		SourceAmount:   25,
+		SourceCurrency: types.CurrencyCodeUSD,
+		TargetAmount:   38000,
+		TargetCurrency: types.CurrencyCodeNGN,
+		Rate:           1520,
+		QuotedAt:       time.Now(),
+		ExpiresAt:      time.Now().Add(time.Minute),
+	}
+	customer := types.InitiateHostedPaymentInput{
+		Reference: "ref123",
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john.doe@example.com",
+	}
+
+	server := quotedPaymentServer(t, 1525)
+	defer server.Close()
+	client := dummyClient(t, server)
+
+	result, err := client.InitiateQuotedPayment(quote, customer)
+	assert.NoError(t, err)
+	assert.False(t, result.Repriced)
+	assert.Equal(t, "1", result.Payment.Id)
+
+	moved := quotedPaymentServer(t, 1600)
+	defer moved.Close()
+	client = dummyClient(t, moved)
+
+	_, err = client.InitiateQuotedPayment(quote, customer, cashrampsdk.WithRateTolerance(0.01))
+	var movedErr *cashrampsdk.RateMovedError
+	assert.ErrorAs(t, err, &movedErr)
+	assert.Equal(t, 1520.0, movedErr.QuotedRate)
+	assert.Equal(t, 1600.0, movedErr.CurrentRate)
+
+	result, err = client.InitiateQuotedPayment(quote, customer, cashrampsdk.WithRateTolerance(0.01), cashrampsdk.WithRepricing())
+	assert.NoError(t, err)
+	assert.True(t, result.Repriced)
+	assert.Equal(t, 40000.0, result.Quote.TargetAmount)
+	assert.Equal(t, 38000.0, quote.TargetAmount)
+
+	expired := *quote
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	_, err = client.InitiateQuotedPayment(&expired, customer, cashrampsdk.WithRepricing())
+	assert.ErrorIs(t, err, cashrampsdk.ErrQuoteExpired)
+}
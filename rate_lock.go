@@ -0,0 +1,127 @@
+package cashrampsdk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// DefaultRateTolerance is the relative rate movement InitiateQuotedPayment
+// accepts when no tolerance is configured: half a percent.
+const DefaultRateTolerance = 0.005
+
+var ErrQuoteExpired = errors.New("quote has expired")
+
+// RateMovedError reports that the market rate moved further than the
+// configured tolerance since the quote was issued.
+type RateMovedError struct {
+	QuotedRate  float64
+	CurrentRate float64
+	Tolerance   float64
+}
+
+// Movement is the relative change from the quoted to the current rate.
+func (e *RateMovedError) Movement() float64 {
+	return rateMovement(e.QuotedRate, e.CurrentRate)
+}
+
+func (e *RateMovedError) Error() string {
+	return fmt.Sprintf("market rate moved from %v to %v (%.2f%%), beyond the %.2f%% tolerance", e.QuotedRate, e.CurrentRate, e.Movement()*100, e.Tolerance*100)
+}
+
+func rateMovement(quoted, current float64) float64 {
+	return math.Abs(current-quoted) / quoted
+}
+
+// RateLockOption configures InitiateQuotedPayment.
+type RateLockOption func(*rateLockOptions)
+
+type rateLockOptions struct {
+	tolerance float64
+	reprice   bool
+}
+
+// WithRateTolerance sets the relative rate movement to accept, e.g. 0.01 for
+// one percent.
+func WithRateTolerance(tolerance float64) RateLockOption {
+	return func(o *rateLockOptions) {
+		o.tolerance = tolerance
+	}
+}
+
+// WithRepricing initiates the payment at the current rate when it moved
+// beyond tolerance, instead of returning a *RateMovedError. Expired quotes are
+// still refused.
+func WithRepricing() RateLockOption {
+	return func(o *rateLockOptions) {
+		o.reprice = true
+	}
+}
+
+// QuotedPayment is the result of InitiateQuotedPayment. Quote is the quote the
+// payment was initiated at, which differs from the one passed in when
+// Repriced is set.
+type QuotedPayment struct {
+	Payment  *types.HostedPaymentResponse
+	Quote    *types.Quote
+	Repriced bool
+	// RateMovement is the relative change between the quoted and current rate.
+	RateMovement float64
+}
+
+// InitiateQuotedPayment initiates a hosted payment for the local currency
+// amount in quote. The market rate is re-fetched first: an expired quote is
+// refused with ErrQuoteExpired, and a rate that moved beyond tolerance is
+// refused with a *RateMovedError unless WithRepricing is set. payment supplies
+// the customer details; its amount, currency, country and payment type are
+// taken from the quote.
+func (c *Client) InitiateQuotedPayment(quote *types.Quote, payment types.InitiateHostedPaymentInput, opts ...RateLockOption) (*QuotedPayment, error) {
+	options := &rateLockOptions{tolerance: DefaultRateTolerance}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if quote.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w at %s", ErrQuoteExpired, quote.ExpiresAt.Format(time.RFC3339))
+	}
+
+	marketRate, err := c.GetMarketRate(quote.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+	currentRate, err := marketRate.RateFor(quote.PaymentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QuotedPayment{Quote: quote, RateMovement: rateMovement(quote.Rate, currentRate)}
+	if result.RateMovement > options.tolerance {
+		if !options.reprice {
+			return nil, &RateMovedError{QuotedRate: quote.Rate, CurrentRate: currentRate, Tolerance: options.tolerance}
+		}
+
+		repriced := *quote
+		repriced.Rate = currentRate
+		repriced.QuotedAt = time.Now()
+		repriced.ExpiresAt = repriced.QuotedAt.Add(quote.ExpiresAt.Sub(quote.QuotedAt))
+		repriced.TargetAmount, err = types.ConvertAmount(quote.SourceAmount, currentRate, quote.Direction, quote.TargetCurrency, quote.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		result.Quote, result.Repriced = &repriced, true
+	}
+
+	payment.PaymentType = result.Quote.PaymentType
+	payment.CountryCode = result.Quote.CountryCode
+	payment.Amount = result.Quote.LocalAmount()
+	payment.Currency = result.Quote.LocalCurrency()
+
+	result.Payment, err = c.InitiateHostedPayment(payment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
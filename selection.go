@@ -0,0 +1,138 @@
+package cashrampsdk
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/rockets-hq/cashramp-sdk/queries"
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+type selectedDocumentKey struct {
+	document string
+	name     string
+	target   reflect.Type
+}
+
+// selectedDocuments caches the built-in documents rewritten by
+// selectDocument. Other documents are rewritten on every call, as
+// parseDocument does, so caller text is never kept.
+var selectedDocuments sync.Map
+
+// selectDocument rewrites document so the root field name selects the JSON
+// fields of target.
+func selectDocument(document, name string, target reflect.Type) (string, error) {
+	key := selectedDocumentKey{document, name, target}
+	if cached, ok := selectedDocuments.Load(key); ok {
+		return cached.(string), nil
+	}
+
+	doc, err := graphql.Parse(document)
+	if err != nil {
+		return "", err
+	}
+	selections, err := graphql.SelectionFor(target)
+	if err != nil {
+		return "", err
+	}
+	if err := graphql.ReplaceSelection(doc, name, selections); err != nil {
+		return "", err
+	}
+
+	selected := doc.String()
+	if _, builtIn := persistedQueryHashes[document]; builtIn {
+		selectedDocuments.Store(key, selected)
+	}
+	return selected, nil
+}
+
+// SendRequestSelected is SendRequestTyped with the selection set of the root
+// field name derived from T, so extending a response struct is enough to
+// request and decode extra fields:
+//
+//	type CountryWithCurrency struct {
+//		types.Country
+//		Currency struct {
+//			IsoCode string `json:"isoCode"`
+//			Name    string `json:"name"`
+//		} `json:"currency"`
+//	}
+func SendRequestSelected[T any](client *Client, name, document string, variables any, opts ...RequestOption) (T, error) {
+	var out T
+	selected, err := selectDocument(document, name, reflect.TypeFor[T]())
+	if err != nil {
+		return out, err
+	}
+	return SendRequestTyped[T](client, name, selected, variables, opts...)
+}
+
+// GetAvailableCountriesAs is GetAvailableCountries decoding into T, which
+// selects its own fields. See SendRequestSelected.
+func GetAvailableCountriesAs[T any](c *Client) ([]T, error) {
+	return SendRequestSelected[[]T](c, "availableCountries", queries.AVAILABLE_COUNTRIES, nil)
+}
+
+// GetMarketRateAs is GetMarketRate decoding into T.
+func GetMarketRateAs[T any](c *Client, countryCode types.CountryCode) (*T, error) {
+	if err := countryCode.Validate(); err != nil {
+		return nil, err
+	}
+
+	marketRate, err := SendRequestSelected[T](c, "marketRate", queries.MARKET_RATE, marketRateVariables{CountryCode: countryCode})
+	if err != nil {
+		return nil, err
+	}
+	return &marketRate, nil
+}
+
+// GetPaymentMethodTypesAs is GetPaymentMethodTypes decoding into T.
+func GetPaymentMethodTypesAs[T any](c *Client, countryId string) ([]T, error) {
+	return SendRequestSelected[[]T](c, "p2pPaymentMethodTypes", queries.PAYMENT_METHOD_TYPES, paymentMethodTypesVariables{Country: countryId})
+}
+
+// GetRampableAssetsAs is GetRampableAssets decoding into T.
+func GetRampableAssetsAs[T any](c *Client) ([]T, error) {
+	return SendRequestSelected[[]T](c, "rampableAssets", queries.RAMPABLE_ASSETS, nil)
+}
+
+// GetRampLimitsAs is GetRampLimits decoding into T.
+func GetRampLimitsAs[T any](c *Client) (*T, error) {
+	rampLimits, err := SendRequestSelected[T](c, "rampLimits", queries.RAMP_LIMITS, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &rampLimits, nil
+}
+
+// GetPaymentRequestAs is GetPaymentRequest decoding into T.
+func GetPaymentRequestAs[T any](c *Client, reference string) (*T, error) {
+	paymentRequest, err := SendRequestSelected[T](c, "merchantPaymentRequest", queries.PAYMENT_REQUEST, paymentRequestVariables{Reference: reference})
+	if err != nil {
+		return nil, err
+	}
+	return &paymentRequest, nil
+}
+
+// GetAccountAs is GetAccount decoding into T.
+func GetAccountAs[T any](c *Client) (*T, error) {
+	account, err := SendRequestSelected[T](c, "account", queries.ACCOUNT, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// GetCustomerAs is GetCustomer decoding into T, e.g. a struct embedding
+// types.Customer that selects extra fields.
+func GetCustomerAs[T any](c *Client, customerID string) (*T, error) {
+	if err := requireField("id", customerID); err != nil {
+		return nil, err
+	}
+
+	customer, err := SendRequestSelected[T](c, "customer", queries.CUSTOMER, customerVariables{ID: customerID})
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
@@ -0,0 +1,54 @@
+package cashrampsdk_test
+
+import (
+	"net/http"
+	"testing"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type countryWithCurrency struct {
+	types.Country
+	Currency struct {
+		IsoCode types.CurrencyCode `json:"isoCode"`
+		Name    string             `json:"name"`
+	} `json:"currency"`
+}
+
+func TestGetAvailableCountriesAs(t *testing.T) {
+	mockResult := []map[string]any{
+		{"id": "1", "name": "Nigeria", "code": "NG", "currency": map[string]string{"isoCode": "NGN", "name": "Naira"}},
+	}
+	responseBytes := createMockGraphQLResponse(t, "availableCountries", mockResult)
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, `currency {\n      isoCode\n      name\n    }`)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	countries, err := cashrampsdk.GetAvailableCountriesAs[countryWithCurrency](client)
+	assert.NoError(t, err)
+	assert.Len(t, countries, 1)
+	assert.Equal(t, types.CountryCodeNG, countries[0].Code)
+	assert.Equal(t, types.CurrencyCodeNGN, countries[0].Currency.IsoCode)
+	assert.Equal(t, "Naira", countries[0].Currency.Name)
+}
+
+func TestGetPaymentRequestAsSelectsOnlyStructFields(t *testing.T) {
+	type paymentStatus struct {
+		Status types.PaymentStatus `json:"status"`
+		Link   string              `json:"link" graphql:"hostedLink"`
+	}
+
+	responseBytes := createMockGraphQLResponse(t, "merchantPaymentRequest", map[string]any{"status": "completed", "link": "https://payment-link.com"})
+	server := mockGraphQLServer(t, responseBytes, http.StatusOK, true, `link: hostedLink`, `"reference":"ref123"`)
+	defer server.Close()
+
+	client := dummyClient(t, server)
+
+	paymentRequest, err := cashrampsdk.GetPaymentRequestAs[paymentStatus](client, "ref123")
+	assert.NoError(t, err)
+	assert.Equal(t, types.PaymentStatusCompleted, paymentRequest.Status)
+	assert.Equal(t, "https://payment-link.com", paymentRequest.Link)
+}
@@ -0,0 +1,450 @@
+package cashrampsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/internal/websocket"
+	"github.com/rockets-hq/cashramp-sdk/subscriptions"
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// graphqlTransportWS is the subprotocol subscriptions are run over.
+const graphqlTransportWS = "graphql-transport-ws"
+
+// Defaults for SubscriptionOptions.
+const (
+	DefaultSubscriptionKeepAlive   = 15 * time.Second
+	DefaultSubscriptionInitTimeout = 10 * time.Second
+	DefaultSubscriptionRetries     = 10
+)
+
+// SubscriptionError reports GraphQL errors returned for a subscription,
+// which end it.
+type SubscriptionError struct {
+	Messages []string
+}
+
+func (e *SubscriptionError) Error() string {
+	return fmt.Sprintf("subscription failed: %s", strings.Join(e.Messages, "; "))
+}
+
+// SubscriptionOption configures Subscribe.
+type SubscriptionOption func(*subscriptionOptions)
+
+type subscriptionOptions struct {
+	url         string
+	keepAlive   time.Duration
+	initTimeout time.Duration
+	retries     int
+	minDelay    time.Duration
+	maxDelay    time.Duration
+}
+
+// validate rejects intervals the connection loop cannot run with, such as a
+// zero keep-alive, which would otherwise panic in a background goroutine.
+func (o *subscriptionOptions) validate() error {
+	switch {
+	case o.keepAlive <= 0:
+		return fmt.Errorf("subscription keep-alive interval must be positive, got %s", o.keepAlive)
+	case o.initTimeout <= 0:
+		return fmt.Errorf("subscription init timeout must be positive, got %s", o.initTimeout)
+	case o.retries < 0:
+		return fmt.Errorf("subscription retries must not be negative, got %d", o.retries)
+	case o.minDelay < 0 || o.minDelay > o.maxDelay:
+		return fmt.Errorf("subscription retry delays must satisfy 0 <= minimum <= maximum, got %s and %s", o.minDelay, o.maxDelay)
+	}
+	return nil
+}
+
+// WithSubscriptionURL sets the WebSocket endpoint. By default it is the
+// client's ApiUrl with the scheme changed to ws or wss.
+func WithSubscriptionURL(url string) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.url = url
+	}
+}
+
+// WithSubscriptionKeepAlive sets how often the client pings the server. A
+// connection that delivers nothing, not even a pong, for two intervals is
+// considered dead and replaced. Subscribe returns an error if interval is not
+// positive.
+func WithSubscriptionKeepAlive(interval time.Duration) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.keepAlive = interval
+	}
+}
+
+// WithSubscriptionRetry sets how many consecutive reconnection attempts are
+// made after a connection drops, and the bounds of the exponential backoff
+// between them.
+func WithSubscriptionRetry(retries int, minDelay, maxDelay time.Duration) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.retries = retries
+		o.minDelay = minDelay
+		o.maxDelay = maxDelay
+	}
+}
+
+// Subscription delivers the events of a running GraphQL subscription.
+type Subscription[T any] struct {
+	events chan T
+	cancel context.CancelFunc
+	done   chan struct{}
+	closed atomic.Bool
+	err    error
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends, after which Err reports why.
+func (s *Subscription[T]) Events() <-chan T {
+	return s.events
+}
+
+// Err returns nil if the server completed the subscription or Close ended
+// it, the context's error if it was cancelled, and otherwise the error that
+// ended it. It blocks until the subscription has ended.
+func (s *Subscription[T]) Err() error {
+	<-s.done
+	if s.closed.Load() {
+		return nil
+	}
+	return s.err
+}
+
+// Close ends the subscription, telling the server it is complete.
+func (s *Subscription[T]) Close() error {
+	s.closed.Store(true)
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// Subscribe starts a subscription to document over a WebSocket using the
+// graphql-transport-ws protocol, decoding each event's root field name into
+// T. The connection is established before Subscribe returns. If it later
+// drops it is re-established and the subscription resent, and server pings
+// are answered. Cancelling ctx ends the subscription.
+func Subscribe[T any](ctx context.Context, client *Client, name, document string, variables any, opts ...SubscriptionOption) (*Subscription[T], error) {
+	operationName, err := resolveOperationName(document, "")
+	if err != nil {
+		return nil, err
+	}
+	s := &subscriber{
+		client:  client,
+		name:    name,
+		request: reqBody{Query: document, OperationName: operationName, Variables: variables},
+		options: subscriptionOptions{
+			keepAlive:   DefaultSubscriptionKeepAlive,
+			initTimeout: DefaultSubscriptionInitTimeout,
+			retries:     DefaultSubscriptionRetries,
+			minDelay:    time.Second,
+			maxDelay:    30 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	if err := s.options.validate(); err != nil {
+		return nil, err
+	}
+	if s.options.url == "" {
+		s.options.url = websocketURL(client.ApiUrl)
+	}
+
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	subscription := &Subscription[T]{events: make(chan T, 16), cancel: cancel, done: make(chan struct{})}
+	deliver := func(data json.RawMessage) error {
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		select {
+		case subscription.events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	go func() {
+		defer close(subscription.done)
+		defer close(subscription.events)
+		subscription.err = s.run(ctx, conn, deliver)
+	}()
+	return subscription, nil
+}
+
+// SubscribePaymentStatus delivers the payment request with the given
+// reference each time it changes, e.g. when a hosted payment completes.
+func (c *Client) SubscribePaymentStatus(ctx context.Context, reference string, opts ...SubscriptionOption) (*Subscription[types.PaymentRequest], error) {
+	variables := paymentRequestUpdatedVariables{Reference: reference}
+	return Subscribe[types.PaymentRequest](ctx, c, "paymentRequestUpdated", subscriptions.PAYMENT_REQUEST_UPDATED, variables, opts...)
+}
+
+func websocketURL(apiURL string) string {
+	switch {
+	case strings.HasPrefix(apiURL, "https://"):
+		return "wss://" + strings.TrimPrefix(apiURL, "https://")
+	case strings.HasPrefix(apiURL, "http://"):
+		return "ws://" + strings.TrimPrefix(apiURL, "http://")
+	}
+	return apiURL
+}
+
+// subscriptionID identifies the only subscription on each connection.
+const subscriptionID = "1"
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type subscriber struct {
+	client  *Client
+	name    string
+	request reqBody
+	options subscriptionOptions
+}
+
+// run reads events from conn until the subscription ends, reconnecting when
+// the connection drops.
+func (s *subscriber) run(ctx context.Context, conn *websocket.Conn, deliver func(json.RawMessage) error) error {
+	failures := 0
+	for {
+		err := s.listen(ctx, conn, deliver)
+		if err == nil || ctx.Err() != nil || !retryable(err) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for {
+			if failures >= s.options.retries {
+				return fmt.Errorf("subscription lost after %d reconnection attempts: %w", failures, err)
+			}
+			failures++
+			if sleepErr := sleepContext(ctx, backoffDelay(failures, s.options.minDelay, s.options.maxDelay)); sleepErr != nil {
+				return sleepErr
+			}
+			conn, err = s.connect(ctx)
+			if err == nil {
+				failures = 0
+				break
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !retryable(err) {
+				return err
+			}
+		}
+	}
+}
+
+// connect opens a connection, completes connection_init and sends the
+// subscription.
+func (s *subscriber) connect(ctx context.Context) (*websocket.Conn, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, s.options.initTimeout)
+	defer cancel()
+
+	header := http.Header{}
+	header.Set("Sec-WebSocket-Protocol", graphqlTransportWS)
+	header.Set("Authorization", fmt.Sprintf("Bearer %v", s.client.secretKey))
+	conn, err := websocket.Dial(dialCtx, s.options.url, header)
+	if err != nil {
+		return nil, err
+	}
+
+	init, err := json.Marshal(map[string]string{"Authorization": fmt.Sprintf("Bearer %v", s.client.secretKey)})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := writeMessage(conn, wsMessage{Type: "connection_init", Payload: init}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(s.options.initTimeout))
+	for acked := false; !acked; {
+		message, err := readMessage(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		switch message.Type {
+		case "connection_ack":
+			acked = true
+		case "ping":
+			err = writeMessage(conn, wsMessage{Type: "pong"})
+		case "pong":
+		default:
+			err = fmt.Errorf("unexpected %q message before connection_ack", message.Type)
+		}
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	payload, err := json.Marshal(s.request)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := writeMessage(conn, wsMessage{ID: subscriptionID, Type: "subscribe", Payload: payload}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// listen delivers events from conn until the subscription completes, fails
+// or the connection drops. It closes conn before returning.
+func (s *subscriber) listen(ctx context.Context, conn *websocket.Conn, deliver func(json.RawMessage) error) error {
+	defer conn.Close()
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		ticker := time.NewTicker(s.options.keepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				writeMessage(conn, wsMessage{Type: "ping"})
+			case <-ctx.Done():
+				writeMessage(conn, wsMessage{ID: subscriptionID, Type: "complete"})
+				conn.WriteClose(websocket.CloseNormalClosure, "")
+				conn.Close()
+				return
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(2 * s.options.keepAlive))
+		message, err := readMessage(conn)
+		if err != nil {
+			return err
+		}
+
+		switch message.Type {
+		case "next":
+			var result struct {
+				Data   map[string]json.RawMessage `json:"data"`
+				Errors []graphqlErrorResponse     `json:"errors"`
+			}
+			if err := json.Unmarshal(message.Payload, &result); err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				return subscriptionError(result.Errors)
+			}
+			if err := deliver(result.Data[s.name]); err != nil {
+				return err
+			}
+		case "error":
+			var errs []graphqlErrorResponse
+			if err := json.Unmarshal(message.Payload, &errs); err != nil {
+				return err
+			}
+			return subscriptionError(errs)
+		case "complete":
+			conn.WriteClose(websocket.CloseNormalClosure, "")
+			return nil
+		case "ping":
+			if err := writeMessage(conn, wsMessage{Type: "pong"}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func subscriptionError(errs []graphqlErrorResponse) *SubscriptionError {
+	subscriptionErr := &SubscriptionError{}
+	for _, graphqlErr := range errs {
+		subscriptionErr.Messages = append(subscriptionErr.Messages, graphqlErr.Message)
+	}
+	return subscriptionErr
+}
+
+func writeMessage(conn *websocket.Conn, message wsMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func readMessage(conn *websocket.Conn) (wsMessage, error) {
+	var message wsMessage
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return message, err
+	}
+	return message, json.Unmarshal(data, &message)
+}
+
+// retryable reports whether a subscription that failed with err may succeed
+// on a new connection. GraphQL errors, rejected handshakes and the 44xx
+// close codes the protocol uses for client errors such as 4401 Unauthorized
+// are permanent.
+func retryable(err error) bool {
+	var subscriptionErr *SubscriptionError
+	var closeErr *websocket.CloseError
+	var handshakeErr *websocket.HandshakeError
+	switch {
+	case errors.As(err, &subscriptionErr):
+		return false
+	case errors.As(err, &closeErr):
+		return closeErr.Code < 4400 || closeErr.Code >= 4500
+	case errors.As(err, &handshakeErr):
+		return handshakeErr.StatusCode == 0 || handshakeErr.StatusCode >= 500
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr)
+}
+
+// backoffDelay returns the delay before retry attempt (counting from 1):
+// exponential from minDelay up to maxDelay, with the upper half jittered so
+// clients do not retry in lockstep.
+func backoffDelay(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + rand.N(delay/2)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
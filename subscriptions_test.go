@@ -0,0 +1,387 @@
+package cashrampsdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/internal/websocket"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsPeer is the server side of one graphql-transport-ws connection.
+type wsPeer struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+func (p *wsPeer) send(id, messageType string, payload any) {
+	message := map[string]any{"type": messageType}
+	if id != "" {
+		message["id"] = id
+	}
+	if payload != nil {
+		message["payload"] = payload
+	}
+	data, err := json.Marshal(message)
+	assert.NoError(p.t, err)
+	assert.NoError(p.t, p.conn.WriteMessage(websocket.TextMessage, data))
+}
+
+func (p *wsPeer) receive() (wsMessage, error) {
+	var message wsMessage
+	_, data, err := p.conn.ReadMessage()
+	if err != nil {
+		return message, err
+	}
+	return message, json.Unmarshal(data, &message)
+}
+
+// expect reads the next message and checks its type.
+func (p *wsPeer) expect(messageType string) wsMessage {
+	message, err := p.receive()
+	assert.NoError(p.t, err)
+	assert.Equal(p.t, messageType, message.Type)
+	return message
+}
+
+// acceptSubscription completes connection_init and reads the subscribe
+// message, returning its payload.
+func (p *wsPeer) acceptSubscription() map[string]any {
+	init := p.expect("connection_init")
+	assert.JSONEq(p.t, `{"Authorization":"Bearer dummy-secret"}`, string(init.Payload))
+	p.send("", "connection_ack", nil)
+
+	subscribe := p.expect("subscribe")
+	assert.Equal(p.t, "1", subscribe.ID)
+	var payload map[string]any
+	assert.NoError(p.t, json.Unmarshal(subscribe.Payload, &payload))
+	return payload
+}
+
+func (p *wsPeer) next(payment types.PaymentRequest) {
+	p.send("1", "next", map[string]any{"data": map[string]any{"paymentRequestUpdated": payment}})
+}
+
+// mockSubscriptionServer runs handle for each connection, counting them.
+func mockSubscriptionServer(t *testing.T, handle func(peer *wsPeer, connection int)) (*httptest.Server, *atomic.Int32) {
+	connections := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer dummy-secret", r.Header.Get("Authorization"))
+		conn, err := websocket.Upgrade(w, r, []string{"graphql-transport-ws"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		assert.Equal(t, "graphql-transport-ws", conn.Subprotocol())
+		handle(&wsPeer{t: t, conn: conn}, int(connections.Add(1)))
+	}))
+	return server, connections
+}
+
+func subscriptionClient(t *testing.T, server *httptest.Server) *cashrampsdk.Client {
+	client := dummyClient(t, server)
+	client.ApiUrl = server.URL + "/graphql"
+	return client
+}
+
+func receiveEvent[T any](t *testing.T, events <-chan T) T {
+	select {
+	case event, ok := <-events:
+		assert.True(t, ok, "events closed")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	var zero T
+	return zero
+}
+
+func TestSubscribePaymentStatus(t *testing.T) {
+	server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		payload := peer.acceptSubscription()
+		assert.Equal(t, "PaymentRequestUpdated", payload["operationName"])
+		assert.Equal(t, map[string]any{"reference": "order_42"}, payload["variables"])
+		assert.Contains(t, payload["query"], "paymentRequestUpdated(reference: $reference)")
+
+		peer.next(types.PaymentRequest{Reference: "order_42", Status: types.PaymentStatusPickedUp})
+		peer.next(types.PaymentRequest{Reference: "order_42", Status: types.PaymentStatusCompleted})
+		peer.send("1", "complete", nil)
+		peer.receive()
+	})
+	defer server.Close()
+
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var statuses []types.PaymentStatus
+	for payment := range subscription.Events() {
+		assert.Equal(t, "order_42", payment.Reference)
+		statuses = append(statuses, payment.Status)
+	}
+	assert.Equal(t, []types.PaymentStatus{types.PaymentStatusPickedUp, types.PaymentStatusCompleted}, statuses)
+	assert.NoError(t, subscription.Err())
+	assert.Equal(t, int32(1), connections.Load())
+}
+
+func TestSubscribeReconnects(t *testing.T) {
+	server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		peer.acceptSubscription()
+		switch connection {
+		case 1:
+			peer.next(types.PaymentRequest{Status: types.PaymentStatusCreated})
+			// Drop the connection without a closing handshake.
+		case 2:
+			peer.next(types.PaymentRequest{Status: types.PaymentStatusCompleted})
+			peer.send("1", "complete", nil)
+			peer.receive()
+		}
+	})
+	defer server.Close()
+
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42",
+		cashrampsdk.WithSubscriptionRetry(3, time.Millisecond, 10*time.Millisecond))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, types.PaymentStatusCreated, receiveEvent(t, subscription.Events()).Status)
+	assert.Equal(t, types.PaymentStatusCompleted, receiveEvent(t, subscription.Events()).Status)
+	assert.NoError(t, subscription.Err())
+	assert.Equal(t, int32(2), connections.Load())
+}
+
+func TestSubscribeGivesUpAfterRetries(t *testing.T) {
+	server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		if connection == 1 {
+			peer.acceptSubscription()
+		}
+	})
+	defer server.Close()
+
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42",
+		cashrampsdk.WithSubscriptionRetry(2, time.Millisecond, time.Millisecond))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for range subscription.Events() {
+	}
+	assert.ErrorContains(t, subscription.Err(), "after 2 reconnection attempts")
+	assert.Equal(t, int32(3), connections.Load())
+}
+
+func TestSubscribeHeartbeat(t *testing.T) {
+	pinged := make(chan struct{})
+	server, _ := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		peer.acceptSubscription()
+		peer.send("", "ping", nil)
+		peer.expect("pong")
+
+		// The client pings on its own once the keepalive interval passes.
+		peer.expect("ping")
+		peer.send("", "pong", nil)
+		close(pinged)
+
+		peer.next(types.PaymentRequest{Status: types.PaymentStatusCompleted})
+		peer.send("1", "complete", nil)
+		peer.receive()
+	})
+	defer server.Close()
+
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42",
+		cashrampsdk.WithSubscriptionKeepAlive(50*time.Millisecond))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, types.PaymentStatusCompleted, receiveEvent(t, subscription.Events()).Status)
+	assert.NoError(t, subscription.Err())
+	<-pinged
+}
+
+func TestSubscribeContextCancel(t *testing.T) {
+	completed := make(chan wsMessage, 1)
+	server, _ := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		peer.acceptSubscription()
+		peer.next(types.PaymentRequest{Status: types.PaymentStatusCreated})
+		for {
+			message, err := peer.receive()
+			if err != nil {
+				return
+			}
+			if message.Type == "complete" {
+				completed <- message
+			}
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(ctx, "order_42")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, types.PaymentStatusCreated, receiveEvent(t, subscription.Events()).Status)
+	cancel()
+	for range subscription.Events() {
+	}
+	assert.ErrorIs(t, subscription.Err(), context.Canceled)
+
+	select {
+	case message := <-completed:
+		assert.Equal(t, "1", message.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not receive complete")
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	server, _ := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		peer.acceptSubscription()
+		peer.expect("complete")
+	})
+	defer server.Close()
+
+	subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, subscription.Close())
+	assert.NoError(t, subscription.Err())
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+}
+
+func TestSubscribeInvalidOptions(t *testing.T) {
+	server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		peer.acceptSubscription()
+		peer.receive()
+	})
+	defer server.Close()
+	client := subscriptionClient(t, server)
+
+	for _, opt := range []cashrampsdk.SubscriptionOption{
+		cashrampsdk.WithSubscriptionKeepAlive(0),
+		cashrampsdk.WithSubscriptionKeepAlive(-time.Second),
+		cashrampsdk.WithSubscriptionRetry(-1, time.Second, time.Second),
+		cashrampsdk.WithSubscriptionRetry(3, time.Minute, time.Second),
+	} {
+		_, err := client.SubscribePaymentStatus(context.Background(), "order_42", opt)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, int32(0), connections.Load())
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	t.Run("unauthorized close is not retried", func(t *testing.T) {
+		server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+			peer.expect("connection_init")
+			peer.conn.WriteClose(4401, "Unauthorized")
+			peer.receive()
+		})
+		defer server.Close()
+
+		_, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42")
+		var closeErr *websocket.CloseError
+		if assert.ErrorAs(t, err, &closeErr) {
+			assert.Equal(t, 4401, closeErr.Code)
+		}
+		assert.Equal(t, int32(1), connections.Load())
+	})
+
+	t.Run("forbidden close after subscribing is not retried", func(t *testing.T) {
+		server, connections := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+			peer.acceptSubscription()
+			peer.conn.WriteClose(4403, "Forbidden")
+			peer.receive()
+		})
+		defer server.Close()
+
+		subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42",
+			cashrampsdk.WithSubscriptionRetry(3, time.Millisecond, time.Millisecond))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		for range subscription.Events() {
+		}
+		var closeErr *websocket.CloseError
+		if assert.ErrorAs(t, subscription.Err(), &closeErr) {
+			assert.Equal(t, 4403, closeErr.Code)
+		}
+		assert.Equal(t, int32(1), connections.Load())
+	})
+
+	t.Run("graphql errors end the subscription", func(t *testing.T) {
+		server, _ := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+			peer.acceptSubscription()
+			peer.send("1", "error", []map[string]string{{"message": "payment request not found"}})
+			peer.receive()
+		})
+		defer server.Close()
+
+		subscription, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		for range subscription.Events() {
+		}
+		var subscriptionErr *cashrampsdk.SubscriptionError
+		if assert.ErrorAs(t, subscription.Err(), &subscriptionErr) {
+			assert.Equal(t, []string{"payment request not found"}, subscriptionErr.Messages)
+		}
+	})
+
+	t.Run("rejected handshake", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		_, err := subscriptionClient(t, server).SubscribePaymentStatus(context.Background(), "order_42")
+		var handshakeErr *websocket.HandshakeError
+		if assert.ErrorAs(t, err, &handshakeErr) {
+			assert.Equal(t, http.StatusUnauthorized, handshakeErr.StatusCode)
+		}
+	})
+}
+
+func TestSubscribeCustomDocument(t *testing.T) {
+	server, _ := mockSubscriptionServer(t, func(peer *wsPeer, connection int) {
+		payload := peer.acceptSubscription()
+		assert.Equal(t, "Status", payload["operationName"])
+		peer.send("1", "next", map[string]any{"data": map[string]any{"paymentRequestUpdated": map[string]string{"status": "completed"}}})
+		peer.send("1", "complete", nil)
+		peer.receive()
+	})
+	defer server.Close()
+
+	type statusOnly struct {
+		Status types.PaymentStatus `json:"status"`
+	}
+	document := `subscription Status($reference: String!) { paymentRequestUpdated(reference: $reference) { status } }`
+	subscription, err := cashrampsdk.Subscribe[statusOnly](context.Background(), subscriptionClient(t, server),
+		"paymentRequestUpdated", document, map[string]string{"reference": "order_42"},
+		cashrampsdk.WithSubscriptionURL("ws"+strings.TrimPrefix(server.URL, "http")))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, types.PaymentStatusCompleted, receiveEvent(t, subscription.Events()).Status)
+	assert.NoError(t, subscription.Err())
+}
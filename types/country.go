@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CountryCode is an ISO 3166-1 alpha-2 country code, e.g. "NG".
+type CountryCode string
+
+const (
+	CountryCodeBJ CountryCode = "BJ"
+	CountryCodeBW CountryCode = "BW"
+	CountryCodeCI CountryCode = "CI"
+	CountryCodeCM CountryCode = "CM"
+	CountryCodeGH CountryCode = "GH"
+	CountryCodeKE CountryCode = "KE"
+	CountryCodeMW CountryCode = "MW"
+	CountryCodeNG CountryCode = "NG"
+	CountryCodeRW CountryCode = "RW"
+	CountryCodeSN CountryCode = "SN"
+	CountryCodeTG CountryCode = "TG"
+	CountryCodeTZ CountryCode = "TZ"
+	CountryCodeUG CountryCode = "UG"
+	CountryCodeZA CountryCode = "ZA"
+	CountryCodeZM CountryCode = "ZM"
+)
+
+// supportedCountries maps the countries Cashramp operates in to their local
+// currency.
+var supportedCountries = map[CountryCode]CurrencyCode{
+	CountryCodeBJ: CurrencyCodeXOF,
+	CountryCodeBW: CurrencyCodeBWP,
+	CountryCodeCI: CurrencyCodeXOF,
+	CountryCodeCM: CurrencyCodeXAF,
+	CountryCodeGH: CurrencyCodeGHS,
+	CountryCodeKE: CurrencyCodeKES,
+	CountryCodeMW: CurrencyCodeMWK,
+	CountryCodeNG: CurrencyCodeNGN,
+	CountryCodeRW: CurrencyCodeRWF,
+	CountryCodeSN: CurrencyCodeXOF,
+	CountryCodeTG: CurrencyCodeXOF,
+	CountryCodeTZ: CurrencyCodeTZS,
+	CountryCodeUG: CurrencyCodeUGX,
+	CountryCodeZA: CurrencyCodeZAR,
+	CountryCodeZM: CurrencyCodeZMW,
+}
+
+const iso3166 = "" +
+	"AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ " +
+	"BL BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR " +
+	"CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU " +
+	"ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ " +
+	"LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ " +
+	"MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF " +
+	"PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI " +
+	"SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR " +
+	"TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+var iso3166Codes = func() map[CountryCode]struct{} {
+	codes := map[CountryCode]struct{}{}
+	for _, code := range strings.Fields(iso3166) {
+		codes[CountryCode(code)] = struct{}{}
+	}
+	return codes
+}()
+
+// ParseCountryCode normalises s to upper case and checks it against
+// ISO 3166-1 alpha-2.
+func ParseCountryCode(s string) (CountryCode, error) {
+	code := CountryCode(strings.ToUpper(strings.TrimSpace(s)))
+	if err := code.Validate(); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// SupportedCountries returns the countries Cashramp operates in.
+func SupportedCountries() []CountryCode {
+	countries := make([]CountryCode, 0, len(supportedCountries))
+	for _, code := range strings.Fields(iso3166) {
+		if _, ok := supportedCountries[CountryCode(code)]; ok {
+			countries = append(countries, CountryCode(code))
+		}
+	}
+	return countries
+}
+
+func (c CountryCode) String() string {
+	return string(c)
+}
+
+// Validate reports whether c is an assigned ISO 3166-1 alpha-2 code.
+func (c CountryCode) Validate() error {
+	if _, ok := iso3166Codes[c]; !ok {
+		return fmt.Errorf("%q is not a valid ISO 3166-1 alpha-2 country code", string(c))
+	}
+	return nil
+}
+
+func (c CountryCode) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// IsSupported reports whether Cashramp operates in c.
+func (c CountryCode) IsSupported() bool {
+	_, ok := supportedCountries[c]
+	return ok
+}
+
+// Currency returns the local currency Cashramp settles in for c.
+func (c CountryCode) Currency() (CurrencyCode, bool) {
+	currency, ok := supportedCountries[c]
+	return currency, ok
+}
@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// createdRange is the creation time range shared by the list filters. It is
+// inclusive of after and exclusive of before, and a zero bound is open.
+type createdRange struct {
+	after, before time.Time
+}
+
+// addTo sets the non-zero bounds on filter as createdAfter and createdBefore,
+// in RFC 3339 format with any fractional seconds, so the bounds stay exact.
+func (r createdRange) addTo(filter map[string]any) {
+	if !r.after.IsZero() {
+		filter["createdAfter"] = r.after.UTC().Format(time.RFC3339Nano)
+	}
+	if !r.before.IsZero() {
+		filter["createdBefore"] = r.before.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func (r createdRange) validate(v *validator) {
+	if !r.after.IsZero() && !r.before.IsZero() && !r.after.Before(r.before) {
+		v.add("createdBefore", "must be after createdAfter")
+	}
+}
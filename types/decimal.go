@@ -0,0 +1,154 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal amount, for sums that must reconcile to the
+// cent. The zero value is 0. Decimals are immutable; arithmetic returns a new
+// value.
+type Decimal struct {
+	rat *big.Rat
+}
+
+// decimalPattern is the one grammar amounts are accepted in: an optional minus
+// sign and digits with an optional fraction. big.Rat.SetString alone also
+// accepts exponents, fractions, hex, binary and underscore separators, none of
+// which belong in an amount.
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// ParseDecimal parses a plain decimal string such as "-12.50". Exponents,
+// leading plus signs and bare points like ".5" are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{rat: r}, nil
+}
+
+// MustParseDecimal is ParseDecimal for constants, panicking on invalid input.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat converts f from its shortest decimal representation, so
+// 0.1 becomes exactly 0.1.
+func DecimalFromFloat(f float64) Decimal {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	return Decimal{rat: r}
+}
+
+func (d Decimal) value() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return d.rat
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Add(d.value(), other.value())}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Sub(d.value(), other.value())}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{rat: new(big.Rat).Neg(d.value())}
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value().Cmp(other.value())
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Sign returns -1, 0 or +1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round rounds d to places decimal places.
+func (d Decimal) Round(places int, mode RoundingMode) (Decimal, error) {
+	rounded, err := roundRat(d.value(), places, mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{rat: rounded}, nil
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.value().Float64()
+	return f
+}
+
+// String formats d with as many decimal places as it needs, e.g. "12.5".
+func (d Decimal) String() string {
+	// A decimal's denominator is 2^a * 5^b, which needs max(a, b) places.
+	denominator := new(big.Int).Set(d.value().Denom())
+	places := 0
+	for _, factor := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		n := 0
+		quotient, remainder := new(big.Int), new(big.Int)
+		for {
+			quotient.QuoRem(denominator, factor, remainder)
+			if remainder.Sign() != 0 {
+				break
+			}
+			denominator.Set(quotient)
+			n++
+		}
+		places = max(places, n)
+	}
+	return d.value().FloatString(places)
+}
+
+// MarshalJSON encodes d as a JSON string, so no precision is lost to
+// floating point on the way to the API.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one, in the
+// grammar of ParseDecimal.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	raw := string(data)
+	if strings.HasPrefix(raw, `"`) {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(raw)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// LedgerEntryType mirrors the LedgerEntryType GraphQL enum: what moved money
+// in or out of the account.
+type LedgerEntryType string
+
+const (
+	LedgerEntryDeposit           LedgerEntryType = "deposit"
+	LedgerEntryWithdrawal        LedgerEntryType = "withdrawal"
+	LedgerEntryFee               LedgerEntryType = "fee"
+	LedgerEntryPaymentSettlement LedgerEntryType = "payment_settlement"
+)
+
+func (t LedgerEntryType) String() string {
+	return string(t)
+}
+
+func (t LedgerEntryType) IsKnown() bool {
+	switch t {
+	case LedgerEntryDeposit, LedgerEntryWithdrawal, LedgerEntryFee, LedgerEntryPaymentSettlement:
+		return true
+	}
+	return false
+}
+
+// LedgerFilter narrows the entries listed by ListLedgerEntries. Zero fields
+// match every entry, and the created range is inclusive of CreatedAfter and
+// exclusive of CreatedBefore.
+type LedgerFilter struct {
+	Type          LedgerEntryType
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// MarshalJSON encodes f as the LedgerFilter input, leaving out zero fields.
+func (f LedgerFilter) MarshalJSON() ([]byte, error) {
+	filter := map[string]any{}
+	if f.Type != "" {
+		filter["type"] = f.Type
+	}
+	createdRange{f.CreatedAfter, f.CreatedBefore}.addTo(filter)
+	return json.Marshal(filter)
+}
+
+// LedgerBalance is a ledger entry with the account balance after it.
+type LedgerBalance struct {
+	LedgerEntry
+	Balance Decimal
+}
+
+// RunningBalances applies entries to the opening balance in the order they
+// were created, returning each entry with the balance after it. The last
+// balance is the closing balance.
+func RunningBalances(opening Decimal, entries []LedgerEntry) []LedgerBalance {
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b LedgerEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	balances := make([]LedgerBalance, len(sorted))
+	balance := opening
+	for i, entry := range sorted {
+		balance = balance.Add(entry.Amount)
+		balances[i] = LedgerBalance{LedgerEntry: entry, Balance: balance}
+	}
+	return balances
+}
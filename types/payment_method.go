@@ -0,0 +1,167 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PaymentMethodField describes one field a payment method type expects, as
+// returned by the p2pPaymentMethodTypes query.
+type PaymentMethodField struct {
+	Label      string `json:"label"`
+	Identifier string `json:"identifier"`
+	Required   bool   `json:"required"`
+}
+
+// PaymentMethodFieldValue is a value supplied for, or stored against, a
+// payment method field.
+type PaymentMethodFieldValue struct {
+	Identifier string `json:"identifier"`
+	Value      string `json:"value"`
+}
+
+// Field returns the field definition with the given identifier.
+func (p PaymentMethodTypes) Field(identifier string) (PaymentMethodField, bool) {
+	for _, field := range p.Fields {
+		if field.Identifier == identifier {
+			return field, true
+		}
+	}
+	return PaymentMethodField{}, false
+}
+
+// RequiredFields returns the fields that must be supplied when adding a
+// payment method of this type.
+func (p PaymentMethodTypes) RequiredFields() []PaymentMethodField {
+	var required []PaymentMethodField
+	for _, field := range p.Fields {
+		if field.Required {
+			required = append(required, field)
+		}
+	}
+	return required
+}
+
+// NewInput starts an AddPaymentMethodInput for this payment method type.
+func (p PaymentMethodTypes) NewInput(customerID string) AddPaymentMethodInput {
+	return NewAddPaymentMethodInput(customerID, p.ID)
+}
+
+func NewAddPaymentMethodInput(customerID, paymentMethodTypeID string) AddPaymentMethodInput {
+	return AddPaymentMethodInput{
+		CustomerID:          customerID,
+		PaymentMethodTypeID: paymentMethodTypeID,
+	}
+}
+
+// WithField returns a copy of i with identifier set to value, replacing any
+// value already set for identifier.
+func (i AddPaymentMethodInput) WithField(identifier, value string) AddPaymentMethodInput {
+	fields := make([]PaymentMethodFieldValue, 0, len(i.Fields)+1)
+	for _, field := range i.Fields {
+		if field.Identifier != identifier {
+			fields = append(fields, field)
+		}
+	}
+	i.Fields = append(fields, PaymentMethodFieldValue{Identifier: identifier, Value: value})
+	return i
+}
+
+// WithFields returns a copy of i with every entry in values set, in
+// identifier order.
+func (i AddPaymentMethodInput) WithFields(values map[string]string) AddPaymentMethodInput {
+	for _, field := range PaymentMethodFieldValues(values) {
+		i = i.WithField(field.Identifier, field.Value)
+	}
+	return i
+}
+
+// PaymentMethodFieldValues converts an identifier to value map into field
+// values sorted by identifier.
+func PaymentMethodFieldValues(values map[string]string) []PaymentMethodFieldValue {
+	fields := make([]PaymentMethodFieldValue, 0, len(values))
+	for identifier, value := range values {
+		fields = append(fields, PaymentMethodFieldValue{Identifier: identifier, Value: value})
+	}
+	sort.Slice(fields, func(a, b int) bool {
+		return fields[a].Identifier < fields[b].Identifier
+	})
+	return fields
+}
+
+// Deprecated: use PaymentMethod.
+type AddPaymentMethodResponse = PaymentMethod
+
+// FieldValue returns the stored value for identifier.
+func (m PaymentMethod) FieldValue(identifier string) (string, bool) {
+	for _, field := range m.Fields {
+		if field.Identifier == identifier {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// LabelledFieldValue is a stored payment method value together with the
+// definition of its field, for display.
+type LabelledFieldValue struct {
+	PaymentMethodField
+	Value string
+}
+
+// LabelledFields returns the stored values in the order the payment method
+// type defines its fields. Values for identifiers the type does not define
+// follow, labelled with their identifier.
+func (m PaymentMethod) LabelledFields() []LabelledFieldValue {
+	var labelled []LabelledFieldValue
+	for _, field := range m.PaymentMethodType.Fields {
+		if value, ok := m.FieldValue(field.Identifier); ok {
+			labelled = append(labelled, LabelledFieldValue{PaymentMethodField: field, Value: value})
+		}
+	}
+	for _, field := range m.Fields {
+		if _, ok := m.PaymentMethodType.Field(field.Identifier); !ok {
+			labelled = append(labelled, LabelledFieldValue{
+				PaymentMethodField: PaymentMethodField{Label: field.Identifier, Identifier: field.Identifier},
+				Value:              field.Value,
+			})
+		}
+	}
+	return labelled
+}
+
+// ValidatePaymentMethodFields checks the fields in input against the schema
+// advertised by definition: every required identifier must be present with a
+// value, and identifiers must be known and not repeated.
+func ValidatePaymentMethodFields(definition PaymentMethodTypes, input AddPaymentMethodInput) error {
+	v := &validator{}
+	if input.PaymentMethodTypeID != definition.ID {
+		v.add("p2pPaymentMethodType", "%q does not match payment method type %q", input.PaymentMethodTypeID, definition.ID)
+	}
+
+	seen := map[string]bool{}
+	for idx, field := range input.Fields {
+		name := fmt.Sprintf("fields[%d]", idx)
+		if seen[field.Identifier] {
+			v.add(name, "duplicate identifier %q", field.Identifier)
+			continue
+		}
+		seen[field.Identifier] = true
+		if _, ok := definition.Field(field.Identifier); !ok {
+			v.add(name, "unknown identifier %q for %s", field.Identifier, definition.Identifier)
+		}
+	}
+
+	for _, field := range input.Fields {
+		if definitionField, ok := definition.Field(field.Identifier); ok && definitionField.Required && strings.TrimSpace(field.Value) == "" {
+			v.add("fields", "required field %q (%s) is empty", field.Identifier, definitionField.Label)
+		}
+	}
+	for _, field := range definition.RequiredFields() {
+		if !seen[field.Identifier] {
+			v.add("fields", "missing required field %q (%s)", field.Identifier, field.Label)
+		}
+	}
+	return v.err()
+}
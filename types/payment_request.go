@@ -0,0 +1,30 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PaymentRequestFilter narrows the payment requests listed by
+// ListPaymentRequests. Zero fields match every payment request, and the
+// created range is inclusive of CreatedAfter and exclusive of CreatedBefore.
+type PaymentRequestFilter struct {
+	Status        PaymentStatus
+	PaymentType   PaymentType
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// MarshalJSON encodes f as the MerchantPaymentRequestFilter input, leaving
+// out zero fields.
+func (f PaymentRequestFilter) MarshalJSON() ([]byte, error) {
+	filter := map[string]any{}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if f.PaymentType != "" {
+		filter["paymentType"] = f.PaymentType
+	}
+	createdRange{f.CreatedAfter, f.CreatedBefore}.addTo(filter)
+	return json.Marshal(filter)
+}
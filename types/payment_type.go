@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PaymentType mirrors the P2PPaymentTypeType GraphQL enum: whether a customer
+// is paying local currency in (deposit) or receiving it (withdrawal).
+type PaymentType string
+
+const (
+	PaymentTypeDeposit    PaymentType = "deposit"
+	PaymentTypeWithdrawal PaymentType = "withdrawal"
+)
+
+var ErrUnknownPaymentType = errors.New("unknown payment type")
+
+// ParsePaymentType normalises s and returns the matching payment type.
+func ParsePaymentType(s string) (PaymentType, error) {
+	paymentType := PaymentType(strings.ToLower(strings.TrimSpace(s)))
+	if !paymentType.IsKnown() {
+		return paymentType, fmt.Errorf("%w: %q", ErrUnknownPaymentType, s)
+	}
+	return paymentType, nil
+}
+
+func (p PaymentType) String() string {
+	return string(p)
+}
+
+func (p PaymentType) IsKnown() bool {
+	return p == PaymentTypeDeposit || p == PaymentTypeWithdrawal
+}
+
+// Rate picks the side of rate that applies to p: deposits are priced at the
+// deposit rate and withdrawals at the withdrawal rate.
+func (p PaymentType) Rate(rate MarketRate) (float64, error) {
+	switch p {
+	case PaymentTypeDeposit:
+		return rate.DepositRate, nil
+	case PaymentTypeWithdrawal:
+		return rate.WithdrawalRate, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownPaymentType, string(p))
+	}
+}
+
+// RateFor returns the local currency units per USD that apply to paymentType.
+func (r MarketRate) RateFor(paymentType PaymentType) (float64, error) {
+	return paymentType.Rate(r)
+}
+
+// MarshalJSON refuses to encode values outside the enum so a typo is caught
+// before it reaches the API. The zero value encodes as null.
+func (p PaymentType) MarshalJSON() ([]byte, error) {
+	if p == "" {
+		return []byte("null"), nil
+	}
+	if !p.IsKnown() {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPaymentType, string(p))
+	}
+	return json.Marshal(string(p))
+}
+
+// UnmarshalJSON accepts any value so responses carrying payment types added to
+// the API later still decode; use IsKnown to check.
+func (p *PaymentType) UnmarshalJSON(data []byte) error {
+	var raw *string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*p = ""
+		return nil
+	}
+	paymentType, _ := ParsePaymentType(*raw)
+	*p = paymentType
+	return nil
+}
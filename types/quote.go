@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// QuoteDirection is the direction of a currency conversion.
+type QuoteDirection string
+
+const (
+	// QuoteUSDToLocal converts a USD amount into the country's local currency.
+	QuoteUSDToLocal QuoteDirection = "usd_to_local"
+	// QuoteLocalToUSD converts a local currency amount into USD.
+	QuoteLocalToUSD QuoteDirection = "local_to_usd"
+)
+
+// Quote is a conversion priced at a market rate. Rate is the local currency
+// units per USD taken from the side of the market rate that applies to
+// PaymentType.
+type Quote struct {
+	PaymentType    PaymentType
+	CountryCode    CountryCode
+	Direction      QuoteDirection
+	SourceAmount   float64
+	SourceCurrency CurrencyCode
+	TargetAmount   float64
+	TargetCurrency CurrencyCode
+	Rate           float64
+	Rounding       RoundingMode
+	QuotedAt       time.Time
+	ExpiresAt      time.Time
+}
+
+// Expired reports whether the quote's price may no longer be honoured at t.
+func (q Quote) Expired(t time.Time) bool {
+	return !q.ExpiresAt.IsZero() && !t.Before(q.ExpiresAt)
+}
+
+// LocalCurrency returns the non-USD currency of the quote.
+func (q Quote) LocalCurrency() CurrencyCode {
+	if q.Direction == QuoteUSDToLocal {
+		return q.TargetCurrency
+	}
+	return q.SourceCurrency
+}
+
+// LocalAmount returns the local currency side of the quote.
+func (q Quote) LocalAmount() float64 {
+	if q.Direction == QuoteUSDToLocal {
+		return q.TargetAmount
+	}
+	return q.SourceAmount
+}
+
+// USDAmount returns the USD side of the quote.
+func (q Quote) USDAmount() float64 {
+	if q.Direction == QuoteUSDToLocal {
+		return q.SourceAmount
+	}
+	return q.TargetAmount
+}
+
+// ConvertAmount converts amount at rate (local currency units per USD) in the
+// given direction and rounds the result to target's minor units. The
+// arithmetic is exact, so rounding is applied once to the true result.
+func ConvertAmount(amount, rate float64, direction QuoteDirection, target CurrencyCode, mode RoundingMode) (float64, error) {
+	if rate <= 0 {
+		return 0, fmt.Errorf("rate must be greater than zero, got %v", rate)
+	}
+	units := target.MinorUnits()
+	if units < 0 {
+		return 0, target.Validate()
+	}
+
+	exactAmount, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("cannot convert %v", amount)
+	}
+	exactRate, _ := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+
+	var converted *big.Rat
+	switch direction {
+	case QuoteUSDToLocal:
+		converted = exactAmount.Mul(exactAmount, exactRate)
+	case QuoteLocalToUSD:
+		converted = exactAmount.Quo(exactAmount, exactRate)
+	default:
+		return 0, fmt.Errorf("unknown quote direction %q", direction)
+	}
+
+	rounded, err := roundRat(converted, units, mode)
+	if err != nil {
+		return 0, err
+	}
+	result, _ := rounded.Float64()
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode selects how amounts are rounded to a currency's minor units.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest minor unit, ties away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest minor unit, ties to even.
+	RoundHalfEven
+	// RoundDown truncates towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfUp:
+		return "half_up"
+	case RoundHalfEven:
+		return "half_even"
+	case RoundDown:
+		return "down"
+	case RoundUp:
+		return "up"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// Round rounds amount to c's minor units. The amount is rounded from its
+// shortest decimal representation, so 1.005 rounds half up to 1.01 rather than
+// being pulled down by its binary approximation.
+func (c CurrencyCode) Round(amount float64, mode RoundingMode) (float64, error) {
+	units := c.MinorUnits()
+	if units < 0 {
+		return 0, c.Validate()
+	}
+
+	exact, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("cannot round %v", amount)
+	}
+	rounded, err := roundRat(exact, units, mode)
+	if err != nil {
+		return 0, err
+	}
+	result, _ := rounded.Float64()
+	return result, nil
+}
+
+func roundRat(r *big.Rat, places int, mode RoundingMode) (*big.Rat, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+
+	negative := scaled.Sign() < 0
+	scaled.Abs(scaled)
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() != 0 {
+		// Compare the discarded fraction against one half: 2*remainder vs denominator.
+		half := new(big.Int).Lsh(remainder, 1).Cmp(scaled.Denom())
+		var roundAway bool
+		switch mode {
+		case RoundHalfUp:
+			roundAway = half >= 0
+		case RoundHalfEven:
+			roundAway = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+		case RoundDown:
+			roundAway = false
+		case RoundUp:
+			roundAway = true
+		default:
+			return nil, fmt.Errorf("unknown rounding mode %v", mode)
+		}
+		if roundAway {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if negative {
+		quotient.Neg(quotient)
+	}
+	return new(big.Rat).SetFrac(quotient, scale), nil
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PaymentStatus is the lifecycle state of a payment request or onchain
+// withdrawal. Known values are decoded case-insensitively; values the SDK does
+// not know about are preserved as-is so that statuses added to the API later
+// still round-trip.
+type PaymentStatus string
+
+const (
+	PaymentStatusCreated   PaymentStatus = "created"
+	PaymentStatusPickedUp  PaymentStatus = "picked_up"
+	PaymentStatusCompleted PaymentStatus = "completed"
+	PaymentStatusCancelled PaymentStatus = "cancelled"
+	PaymentStatusFailed    PaymentStatus = "failed"
+)
+
+// Deprecated: use PaymentStatusCreated.
+const PaymentStatusTypeCreated = PaymentStatusCreated
+
+// Deprecated: use PaymentStatusPickedUp.
+const PaymnetStatusTypedPickedUp = PaymentStatusPickedUp
+
+// Deprecated: use PaymentStatusCompleted.
+const PaymentStatusTypedCompleted = PaymentStatusCompleted
+
+// Deprecated: use PaymentStatusCancelled.
+const PaymentStatusTypedCancelled = PaymentStatusCancelled
+
+var ErrUnknownPaymentStatus = errors.New("unknown payment status")
+
+// paymentStatusTransitions lists the statuses each known status may move to.
+// Terminal statuses have no outgoing transitions.
+var paymentStatusTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusCreated:   {PaymentStatusPickedUp, PaymentStatusCompleted, PaymentStatusCancelled, PaymentStatusFailed},
+	PaymentStatusPickedUp:  {PaymentStatusCompleted, PaymentStatusCancelled, PaymentStatusFailed},
+	PaymentStatusCompleted: {},
+	PaymentStatusCancelled: {},
+	PaymentStatusFailed:    {},
+}
+
+// ParsePaymentStatus normalises s and returns the matching status. Unknown
+// values are returned alongside an error wrapping ErrUnknownPaymentStatus so
+// callers can decide whether to tolerate them.
+func ParsePaymentStatus(s string) (PaymentStatus, error) {
+	status := PaymentStatus(strings.ToLower(strings.TrimSpace(s)))
+	if !status.IsKnown() {
+		return status, fmt.Errorf("%w: %q", ErrUnknownPaymentStatus, s)
+	}
+	return status, nil
+}
+
+func (s PaymentStatus) String() string {
+	return string(s)
+}
+
+// IsKnown reports whether s is one of the statuses defined by this package.
+func (s PaymentStatus) IsKnown() bool {
+	_, ok := paymentStatusTransitions[s]
+	return ok
+}
+
+// IsTerminal reports whether s is a final status. Unknown statuses are never
+// terminal, so callers waiting on a payment keep waiting rather than stopping
+// on a value they cannot interpret.
+func (s PaymentStatus) IsTerminal() bool {
+	next, ok := paymentStatusTransitions[s]
+	return ok && len(next) == 0
+}
+
+// IsSuccessful reports whether s is the completed status.
+func (s PaymentStatus) IsSuccessful() bool {
+	return s == PaymentStatusCompleted
+}
+
+// CanTransitionTo reports whether moving from s to next is a valid lifecycle
+// step. Staying in the same known status is allowed; transitions involving
+// unknown statuses, including from one to itself, are not.
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	if !s.IsKnown() {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, allowed := range paymentStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PaymentStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	status, err := ParsePaymentStatus(raw)
+	if err != nil {
+		status = PaymentStatus(raw)
+	}
+	*s = status
+	return nil
+}
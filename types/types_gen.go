@@ -0,0 +1,169 @@
+// Code generated by cashrampgen. DO NOT EDIT.
+
+package types
+
+import (
+	"time"
+)
+
+type Country struct {
+	ID   string      `json:"id"`
+	Name string      `json:"name"`
+	Code CountryCode `json:"code"`
+}
+
+type MarketRate struct {
+	DepositRate    float64 `json:"depositRate"`
+	WithdrawalRate float64 `json:"withdrawalRate"`
+}
+
+type PaymentMethodTypes struct {
+	ID         string               `json:"id"`
+	Identifier string               `json:"identifier"`
+	Label      string               `json:"label"`
+	Fields     []PaymentMethodField `json:"fields"`
+}
+
+type RampableAssets struct {
+	Name            string   `json:"name"`
+	Symbol          string   `json:"symbol"`
+	Networks        []string `json:"networks"`
+	ContractAddress string   `json:"contractAddress"`
+}
+
+type RampLimits struct {
+	MinimumDepositUsd    float64 `json:"minimumDepositUsd"`
+	MaximumDepositUsd    float64 `json:"maximumDepositUsd"`
+	MinimumWithdrawalUsd float64 `json:"minimumWithdrawalUsd"`
+	MaximumWithdrawalUsd float64 `json:"maximumWithdrawalUsd"`
+	DailyLimitUsd        float64 `json:"dailyLimitUsd"`
+}
+
+type PaymentRequest struct {
+	ID          string        `json:"id"`
+	PaymentType PaymentType   `json:"paymentType"`
+	HostedLink  string        `json:"hostedLink"`
+	Amount      float64       `json:"amount"`
+	Currency    CurrencyCode  `json:"currency"`
+	Reference   string        `json:"reference"`
+	Status      PaymentStatus `json:"status"`
+}
+
+type PaymentRequestPage struct {
+	Nodes    []PaymentRequest `json:"nodes"`
+	PageInfo PageInfo         `json:"pageInfo"`
+}
+
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type Account struct {
+	ID             string  `json:"id"`
+	AccountBalance float64 `json:"accountBalance"`
+	DepositAddress string  `json:"depositAddress"`
+}
+
+// An entry in the account ledger. Credits have positive amounts and debits
+// negative ones.
+type LedgerEntry struct {
+	ID          string          `json:"id"`
+	Type        LedgerEntryType `json:"type"`
+	Amount      Decimal         `json:"amount"`
+	Reference   string          `json:"reference"`
+	Description string          `json:"description"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+type LedgerPage struct {
+	Nodes    []LedgerEntry `json:"nodes"`
+	PageInfo PageInfo      `json:"pageInfo"`
+}
+
+type HostedPaymentResponse struct {
+	Id         string        `json:"id"`
+	HostedLink string        `json:"hostedLink"`
+	Status     PaymentStatus `json:"status"`
+}
+
+type Customer struct {
+	Id        string  `json:"id"`
+	Email     string  `json:"email"`
+	FirstName string  `json:"firstName"`
+	LastName  string  `json:"lastName"`
+	Country   Country `json:"country"`
+}
+
+type CustomerPage struct {
+	Nodes    []Customer `json:"nodes"`
+	PageInfo PageInfo   `json:"pageInfo"`
+}
+
+type PaymentMethod struct {
+	ID                string                    `json:"id"`
+	Value             string                    `json:"value"`
+	Fields            []PaymentMethodFieldValue `json:"fields"`
+	PaymentMethodType PaymentMethodTypes        `json:"p2pPaymentMethodType"`
+}
+
+type Withdrawal struct {
+	ID              string        `json:"id"`
+	Status          PaymentStatus `json:"status"`
+	Address         string        `json:"address"`
+	Network         string        `json:"network"`
+	TransactionHash string        `json:"transactionHash"`
+	AmountUsd       Decimal       `json:"amountUsd"`
+	FeeUsd          Decimal       `json:"feeUsd"`
+}
+
+type ConfirmTransactionInput struct {
+	PaymentRequest  string `json:"paymentRequest"`
+	TransactionHash string `json:"transactionHash"`
+}
+
+type InitiateHostedPaymentInput struct {
+	PaymentType PaymentType  `json:"paymentType"`
+	Amount      float64      `json:"amount"`
+	Currency    CurrencyCode `json:"currency,omitempty"`
+	CountryCode CountryCode  `json:"countryCode"`
+	Reference   string       `json:"reference"`
+	RedirectUrl string       `json:"redirectUrl,omitempty"`
+	FirstName   string       `json:"firstName"`
+	LastName    string       `json:"lastName"`
+	Email       string       `json:"email"`
+}
+
+type CancelHostedPaymentInput struct {
+	PaymentRequest string `json:"paymentRequest"`
+}
+
+type CreateCustomerInput struct {
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	CountryID string `json:"country"`
+}
+
+type UpdateCustomerInput struct {
+	CustomerID string `json:"customer"`
+	Email      string `json:"email,omitempty"`
+	FirstName  string `json:"firstName,omitempty"`
+	LastName   string `json:"lastName,omitempty"`
+	CountryID  string `json:"country,omitempty"`
+}
+
+type AddPaymentMethodInput struct {
+	CustomerID          string                    `json:"customer"`
+	PaymentMethodTypeID string                    `json:"p2pPaymentMethodType"`
+	Fields              []PaymentMethodFieldValue `json:"fields"`
+}
+
+type RemovePaymentMethodInput struct {
+	PaymentMethodID string `json:"paymentMethod"`
+}
+
+type WithdrawOnchainInput struct {
+	Address string `json:"address"`
+	Amount  string `json:"amountUsd"`
+}
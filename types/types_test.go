@@ -0,0 +1,232 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentStatusLifecycle(t *testing.T) {
+	assert.True(t, types.PaymentStatusCreated.CanTransitionTo(types.PaymentStatusPickedUp))
+	assert.True(t, types.PaymentStatusPickedUp.CanTransitionTo(types.PaymentStatusCompleted))
+	assert.False(t, types.PaymentStatusCompleted.CanTransitionTo(types.PaymentStatusCancelled))
+	assert.False(t, types.PaymentStatusPickedUp.CanTransitionTo(types.PaymentStatusCreated))
+	assert.True(t, types.PaymentStatusCompleted.CanTransitionTo(types.PaymentStatusCompleted))
+
+	refunded := types.PaymentStatus("refunded")
+	assert.False(t, refunded.CanTransitionTo(refunded))
+	assert.False(t, refunded.CanTransitionTo(types.PaymentStatusCompleted))
+	assert.False(t, types.PaymentStatusCreated.CanTransitionTo(refunded))
+
+	assert.True(t, types.PaymentStatusCancelled.IsTerminal())
+	assert.False(t, types.PaymentStatusPickedUp.IsTerminal())
+}
+
+func TestPaymentStatusUnknownValues(t *testing.T) {
+	status, err := types.ParsePaymentStatus("Refunded")
+	assert.ErrorIs(t, err, types.ErrUnknownPaymentStatus)
+	assert.Equal(t, types.PaymentStatus("refunded"), status)
+	assert.False(t, status.IsKnown())
+	assert.False(t, status.IsTerminal())
+
+	var request types.PaymentRequest
+	assert.NoError(t, json.Unmarshal([]byte(`{"status":"REFUNDED"}`), &request))
+	assert.Equal(t, types.PaymentStatus("REFUNDED"), request.Status)
+	assert.NoError(t, json.Unmarshal([]byte(`{"status":"PICKED_UP"}`), &request))
+	assert.Equal(t, types.PaymentStatusPickedUp, request.Status)
+}
+
+func TestPaymentTypeJSON(t *testing.T) {
+	_, err := json.Marshal(types.InitiateHostedPaymentInput{PaymentType: "onramp"})
+	assert.ErrorIs(t, err, types.ErrUnknownPaymentType)
+
+	body, err := json.Marshal(types.InitiateHostedPaymentInput{PaymentType: types.PaymentTypeWithdrawal})
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"paymentType":"withdrawal"`)
+
+	var request types.PaymentRequest
+	assert.NoError(t, json.Unmarshal([]byte(`{"paymentType":"DEPOSIT"}`), &request))
+	assert.Equal(t, types.PaymentTypeDeposit, request.PaymentType)
+}
+
+func TestPaymentRequestFilter(t *testing.T) {
+	body, err := json.Marshal(types.PaymentRequestFilter{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(body))
+
+	lagos := time.FixedZone("WAT", 3600)
+	filter := types.PaymentRequestFilter{
+		Status:       types.PaymentStatusCompleted,
+		PaymentType:  types.PaymentTypeDeposit,
+		CreatedAfter: time.Date(2026, 1, 1, 1, 0, 0, 0, lagos),
+	}
+	assert.NoError(t, filter.Validate())
+	body, err = json.Marshal(filter)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"completed","paymentType":"deposit","createdAfter":"2026-01-01T00:00:00Z"}`, string(body))
+
+	body, err = json.Marshal(types.PaymentRequestFilter{CreatedBefore: time.Date(2026, 1, 1, 12, 0, 0, 500_000_000, time.UTC)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"createdBefore":"2026-01-01T12:00:00.5Z"}`, string(body))
+
+	filter = types.PaymentRequestFilter{
+		Status:        "refunded",
+		CreatedAfter:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var validationErr *types.ValidationError
+	if assert.ErrorAs(t, filter.Validate(), &validationErr) {
+		assert.Len(t, validationErr.Errors, 2)
+		_, ok := validationErr.Field("createdBefore")
+		assert.True(t, ok)
+	}
+}
+
+func TestMarketRateRateFor(t *testing.T) {
+	rate := types.MarketRate{DepositRate: 1520, WithdrawalRate: 1480}
+
+	deposit, err := rate.RateFor(types.PaymentTypeDeposit)
+	assert.NoError(t, err)
+	assert.Equal(t, 1520.0, deposit)
+
+	withdrawal, err := types.PaymentTypeWithdrawal.Rate(rate)
+	assert.NoError(t, err)
+	assert.Equal(t, 1480.0, withdrawal)
+
+	_, err = rate.RateFor("swap")
+	assert.ErrorIs(t, err, types.ErrUnknownPaymentType)
+}
+
+func TestValidatePaymentMethodFields(t *testing.T) {
+	definition := types.PaymentMethodTypes{
+		ID:         "bank",
+		Identifier: "ng_bank",
+		Fields: []types.PaymentMethodField{
+			{Label: "Account Number", Identifier: "account_number", Required: true},
+			{Label: "Account Name", Identifier: "account_name"},
+		},
+	}
+
+	input := definition.NewInput("cus_1")
+	input.Fields = []types.PaymentMethodFieldValue{
+		{Identifier: "account_name", Value: "Ada"},
+		{Identifier: "account_name", Value: "Ada"},
+		{Identifier: "sort_code", Value: "044"},
+	}
+
+	var validationErr *types.ValidationError
+	assert.ErrorAs(t, types.ValidatePaymentMethodFields(definition, input), &validationErr)
+	assert.Len(t, validationErr.Errors, 3)
+	assert.Contains(t, validationErr.Error(), `duplicate identifier "account_name"`)
+	assert.Contains(t, validationErr.Error(), `unknown identifier "sort_code"`)
+	assert.Contains(t, validationErr.Error(), `missing required field "account_number"`)
+
+	assert.NoError(t, types.ValidatePaymentMethodFields(definition, definition.NewInput("cus_1").WithField("account_number", "0123456789")))
+}
+
+func TestCurrencyCodeRound(t *testing.T) {
+	tests := []struct {
+		currency types.CurrencyCode
+		amount   float64
+		mode     types.RoundingMode
+		expected float64
+	}{
+		{types.CurrencyCodeUSD, 1.005, types.RoundHalfUp, 1.01},
+		{types.CurrencyCodeUSD, 1.005, types.RoundHalfEven, 1.0},
+		{types.CurrencyCodeUSD, 1.015, types.RoundHalfEven, 1.02},
+		{types.CurrencyCodeUSD, 1.001, types.RoundUp, 1.01},
+		{types.CurrencyCodeUSD, -1.009, types.RoundDown, -1.0},
+		{types.CurrencyCodeUGX, 1234.5, types.RoundHalfUp, 1235},
+		{types.CurrencyCode("KWD"), 1.2345, types.RoundHalfUp, 1.235},
+	}
+
+	for _, tt := range tests {
+		rounded, err := tt.currency.Round(tt.amount, tt.mode)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, rounded, "%s %v %s", tt.currency, tt.amount, tt.mode)
+	}
+
+	_, err := types.CurrencyCode("ABC").Round(1, types.RoundHalfUp)
+	assert.Error(t, err)
+}
+
+func TestConvertAmount(t *testing.T) {
+	local, err := types.ConvertAmount(10.01, 1520.5, types.QuoteUSDToLocal, types.CurrencyCodeNGN, types.RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, 15220.21, local)
+
+	usd, err := types.ConvertAmount(10000, 1515, types.QuoteLocalToUSD, types.CurrencyCodeUSD, types.RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, 6.6, usd)
+}
+
+func TestDecimal(t *testing.T) {
+	a := types.MustParseDecimal("0.1")
+	b := types.DecimalFromFloat(0.2)
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.True(t, a.Add(b).Equal(types.MustParseDecimal("0.30")))
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, -1, a.Sub(b).Sign())
+	assert.Equal(t, "0", types.Decimal{}.String())
+	assert.Equal(t, "0.0625", types.MustParseDecimal("0.0625").String())
+
+	rounded, err := types.MustParseDecimal("2.345").Round(2, types.RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.34", rounded.String())
+
+	for _, invalid := range []string{"", "1/3", "abc", "0x10", "0b101", "0o17", "1_000", "1e", "-", ".", "0x1p4", "NaN", "Inf",
+		"1.5e3", "1E3", "2e-2", "-.5", ".5", "+12", "12."} {
+		_, err = types.ParseDecimal(invalid)
+		assert.Error(t, err, invalid)
+	}
+	assert.Equal(t, "-0.5", types.MustParseDecimal("-0.5").String())
+	assert.Equal(t, "12", types.MustParseDecimal(" 12 ").String())
+
+	var entry types.LedgerEntry
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":"-12.50"}`), &entry))
+	assert.Equal(t, "-12.5", entry.Amount.String())
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":1234567890.123456789}`), &entry))
+	assert.Equal(t, "1234567890.123456789", entry.Amount.String())
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":1.5e3}`), &entry))
+
+	// Input validation accepts the same grammar as ParseDecimal.
+	assert.NoError(t, types.WithdrawOnchainInput{Address: "0x123", Amount: "25.50"}.Validate())
+	for _, invalid := range []string{"1.5e3", "+25", ".5", "25."} {
+		assert.Error(t, types.WithdrawOnchainInput{Address: "0x123", Amount: invalid}.Validate(), invalid)
+	}
+
+	body, err := json.Marshal(types.MustParseDecimal("10.05"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"10.05"`, string(body))
+}
+
+func TestLedgerFilter(t *testing.T) {
+	body, err := json.Marshal(types.LedgerFilter{
+		Type:          types.LedgerEntryFee,
+		CreatedBefore: time.Date(2026, 3, 1, 12, 0, 0, 500_000_000, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"fee","createdBefore":"2026-03-01T12:00:00.5Z"}`, string(body))
+}
+
+func TestRunningBalances(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	entries := []types.LedgerEntry{
+		{ID: "fee", Type: types.LedgerEntryFee, Amount: types.MustParseDecimal("-0.30"), CreatedAt: day(3)},
+		{ID: "deposit", Type: types.LedgerEntryDeposit, Amount: types.MustParseDecimal("100.10"), CreatedAt: day(1)},
+		{ID: "withdrawal", Type: types.LedgerEntryWithdrawal, Amount: types.MustParseDecimal("-40.20"), CreatedAt: day(2)},
+	}
+
+	balances := types.RunningBalances(types.MustParseDecimal("5"), entries)
+	var ids, running []string
+	for _, balance := range balances {
+		ids = append(ids, balance.ID)
+		running = append(running, balance.Balance.String())
+	}
+	assert.Equal(t, []string{"deposit", "withdrawal", "fee"}, ids)
+	assert.Equal(t, []string{"105.1", "64.9", "64.6"}, running)
+	assert.Equal(t, "fee", entries[0].ID, "entries are not reordered in place")
+}
@@ -0,0 +1,200 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// FieldError describes a single invalid field on an input type. Field is the
+// JSON name the field is sent under.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError collects every invalid field found on an input so callers
+// can report them all at once.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("invalid input: %s", strings.Join(messages, "; "))
+}
+
+// Field returns the error recorded for field, if any.
+func (e *ValidationError) Field(field string) (FieldError, bool) {
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Field == field {
+			return fieldErr, true
+		}
+	}
+	return FieldError{}, false
+}
+
+type validator struct {
+	errors []FieldError
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+		return false
+	}
+	return true
+}
+
+func (v *validator) email(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.add(field, "%q is not a valid email address", value)
+	}
+}
+
+func (v *validator) positive(field string, value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		v.add(field, "must be a finite number")
+	} else if value <= 0 {
+		v.add(field, "must be greater than zero")
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errors}
+}
+
+func (i InitiateHostedPaymentInput) Validate() error {
+	v := &validator{}
+	if v.required("paymentType", string(i.PaymentType)) && !i.PaymentType.IsKnown() {
+		v.add("paymentType", "%q is not one of %q or %q", string(i.PaymentType), PaymentTypeDeposit, PaymentTypeWithdrawal)
+	}
+	v.positive("amount", i.Amount)
+	if i.Currency != "" {
+		if err := i.Currency.Validate(); err != nil {
+			v.add("currency", "%s", err)
+		}
+	}
+	if v.required("countryCode", string(i.CountryCode)) {
+		if err := i.CountryCode.Validate(); err != nil {
+			v.add("countryCode", "%s", err)
+		}
+	}
+	v.required("reference", i.Reference)
+	v.required("firstName", i.FirstName)
+	v.required("lastName", i.LastName)
+	v.email("email", i.Email)
+	if i.RedirectUrl != "" {
+		if u, err := url.Parse(i.RedirectUrl); err != nil || !u.IsAbs() {
+			v.add("redirectUrl", "%q is not an absolute URL", i.RedirectUrl)
+		}
+	}
+	return v.err()
+}
+
+func (i CreateCustomerInput) Validate() error {
+	v := &validator{}
+	v.email("email", i.Email)
+	v.required("firstName", i.FirstName)
+	v.required("lastName", i.LastName)
+	v.required("country", i.CountryID)
+	return v.err()
+}
+
+func (i AddPaymentMethodInput) Validate() error {
+	v := &validator{}
+	v.required("customer", i.CustomerID)
+	v.required("p2pPaymentMethodType", i.PaymentMethodTypeID)
+	if len(i.Fields) == 0 {
+		v.add("fields", "at least one field is required")
+	}
+	for idx, field := range i.Fields {
+		v.required(fmt.Sprintf("fields[%d].identifier", idx), field.Identifier)
+	}
+	return v.err()
+}
+
+func (i WithdrawOnchainInput) Validate() error {
+	v := &validator{}
+	v.required("address", i.Address)
+	if v.required("amountUsd", i.Amount) {
+		amount, err := ParseDecimal(i.Amount)
+		if err != nil || strings.TrimSpace(i.Amount) != i.Amount {
+			v.add("amountUsd", "%q is not a valid decimal amount", i.Amount)
+		} else if amount.Sign() <= 0 {
+			v.add("amountUsd", "must be greater than zero")
+		}
+	}
+	return v.err()
+}
+
+func (i ConfirmTransactionInput) Validate() error {
+	v := &validator{}
+	v.required("paymentRequest", i.PaymentRequest)
+	v.required("transactionHash", i.TransactionHash)
+	return v.err()
+}
+
+func (i CancelHostedPaymentInput) Validate() error {
+	v := &validator{}
+	v.required("paymentRequest", i.PaymentRequest)
+	return v.err()
+}
+
+func (f PaymentRequestFilter) Validate() error {
+	v := &validator{}
+	if f.Status != "" && !f.Status.IsKnown() {
+		v.add("status", "%q is not a known payment status", string(f.Status))
+	}
+	if f.PaymentType != "" && !f.PaymentType.IsKnown() {
+		v.add("paymentType", "%q is not one of %q or %q", string(f.PaymentType), PaymentTypeDeposit, PaymentTypeWithdrawal)
+	}
+	createdRange{f.CreatedAfter, f.CreatedBefore}.validate(v)
+	return v.err()
+}
+
+func (i UpdateCustomerInput) Validate() error {
+	v := &validator{}
+	v.required("customer", i.CustomerID)
+	if i.Email == "" && i.FirstName == "" && i.LastName == "" && i.CountryID == "" {
+		v.add("customer", "nothing to update, set at least one other field")
+	}
+	if i.Email != "" {
+		v.email("email", i.Email)
+	}
+	return v.err()
+}
+
+func (i RemovePaymentMethodInput) Validate() error {
+	v := &validator{}
+	v.required("paymentMethod", i.PaymentMethodID)
+	return v.err()
+}
+
+func (f LedgerFilter) Validate() error {
+	v := &validator{}
+	if f.Type != "" && !f.Type.IsKnown() {
+		v.add("type", "%q is not a known ledger entry type", string(f.Type))
+	}
+	createdRange{f.CreatedAfter, f.CreatedBefore}.validate(v)
+	return v.err()
+}
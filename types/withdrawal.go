@@ -0,0 +1,4 @@
+package types
+
+// Deprecated: use Withdrawal.
+type WithdrawOnchainResponse = Withdrawal
@@ -0,0 +1,480 @@
+// Package validation checks GraphQL documents against a schema snapshot
+// without contacting the server: unknown types, fields and arguments,
+// argument values of the wrong type, missing required arguments, fragments
+// that can never apply, and undefined, unused or mistyped variables.
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+)
+
+// Problem is a single rule violation. Path locates it by operation and
+// response keys, e.g. "query PaymentRequest.merchantPaymentRequest.status".
+type Problem struct {
+	Path    string
+	Message string
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// Error collects every problem found in a document.
+type Error struct {
+	Problems []Problem
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+	}
+	return fmt.Sprintf("invalid document: %s", strings.Join(messages, "; "))
+}
+
+// Validate parses document and checks it against schema. It returns a
+// *graphql.SyntaxError if the document does not parse and an *Error if it
+// breaks any rule.
+func Validate(schema *graphql.Schema, document string) error {
+	doc, err := graphql.Parse(document)
+	if err != nil {
+		return err
+	}
+	return ValidateDocument(schema, doc)
+}
+
+// ValidateDocument checks a parsed document against schema.
+func ValidateDocument(schema *graphql.Schema, doc *graphql.Document) error {
+	v := &validator{schema: schema, doc: doc, usedFragments: map[string]bool{}}
+
+	names := map[string]bool{}
+	for _, op := range doc.Operations {
+		path := string(op.Type)
+		if op.Name != "" {
+			path += " " + op.Name
+			if names[op.Name] {
+				v.addf(path, "operation name %s is used more than once", op.Name)
+			}
+			names[op.Name] = true
+		} else if len(doc.Operations) > 1 {
+			v.addf(path, "anonymous operations must be the only operation in the document")
+		}
+		v.operation(path, op)
+	}
+
+	for _, fragment := range doc.Fragments {
+		if !v.usedFragments[fragment.Name] {
+			v.addf("fragment "+fragment.Name, "fragment is never used")
+		}
+	}
+
+	if len(v.problems) > 0 {
+		return &Error{Problems: v.problems}
+	}
+	return nil
+}
+
+type validator struct {
+	schema        *graphql.Schema
+	doc           *graphql.Document
+	problems      []Problem
+	usedFragments map[string]bool
+}
+
+// scope tracks the operation whose selections, including those reached
+// through fragment spreads, are being checked.
+type scope struct {
+	op            *graphql.Operation
+	usedVariables map[string]bool
+	fragments     map[string]bool
+}
+
+func (v *validator) addf(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) operation(path string, op *graphql.Operation) {
+	s := &scope{op: op, usedVariables: map[string]bool{}, fragments: map[string]bool{}}
+
+	defined := map[string]bool{}
+	for _, variable := range op.Variables {
+		variablePath := path + "($" + variable.Name + ")"
+		if defined[variable.Name] {
+			v.addf(variablePath, "variable $%s is defined more than once", variable.Name)
+		}
+		defined[variable.Name] = true
+
+		def := v.schema.Type(variable.Type.NamedType())
+		switch {
+		case def == nil:
+			v.addf(variablePath, "unknown type %s", variable.Type.NamedType())
+		case !def.IsInput():
+			v.addf(variablePath, "variable $%s must have an input type, not %s", variable.Name, def.Name)
+		case variable.DefaultValue != nil:
+			v.value(nil, variablePath, variable.DefaultValue, variable.Type, false)
+		}
+	}
+
+	root, err := v.schema.RootType(op.Type)
+	if err != nil {
+		v.addf(path, "%s", strings.TrimPrefix(err.Error(), "graphql: "))
+		return
+	}
+	v.selectionSet(s, path, root, op.SelectionSet)
+
+	for _, variable := range op.Variables {
+		if !s.usedVariables[variable.Name] {
+			v.addf(path+"($"+variable.Name+")", "variable $%s is never used", variable.Name)
+		}
+	}
+}
+
+func (v *validator) selectionSet(s *scope, path string, parent *graphql.TypeDefinition, selections graphql.SelectionSet) {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *graphql.Field:
+			v.field(s, path, parent, selection)
+		case *graphql.InlineFragment:
+			v.directives(s, path, selection.Directives)
+			target := parent
+			if selection.TypeCondition != "" {
+				if target = v.typeCondition(path, selection.TypeCondition); target == nil || !v.canApply(path, parent, target) {
+					continue
+				}
+			}
+			v.selectionSet(s, path, target, selection.SelectionSet)
+		case *graphql.FragmentSpread:
+			v.directives(s, path, selection.Directives)
+			v.usedFragments[selection.Name] = true
+			fragment := v.doc.Fragment(selection.Name)
+			if fragment == nil {
+				v.addf(path, "unknown fragment %s", selection.Name)
+				continue
+			}
+			if s.fragments[fragment.Name] {
+				v.addf(path, "fragment %s spreads itself", fragment.Name)
+				continue
+			}
+			target := v.typeCondition(path, fragment.TypeCondition)
+			if target == nil || !v.canApply(path, parent, target) {
+				continue
+			}
+			s.fragments[fragment.Name] = true
+			v.directives(s, path, fragment.Directives)
+			v.selectionSet(s, path, target, fragment.SelectionSet)
+			delete(s.fragments, fragment.Name)
+		}
+	}
+}
+
+func (v *validator) typeCondition(path, name string) *graphql.TypeDefinition {
+	def := v.schema.Type(name)
+	switch {
+	case def == nil:
+		v.addf(path, "unknown type %s", name)
+		return nil
+	case def.IsLeaf() || def.Kind == graphql.KindInputObject:
+		v.addf(path, "fragments cannot be on %s type %s", strings.ToLower(string(def.Kind)), name)
+		return nil
+	}
+	return def
+}
+
+// canApply reports whether a fragment on target can match a value of type
+// parent, which needs an object type both can be.
+func (v *validator) canApply(path string, parent, target *graphql.TypeDefinition) bool {
+	targetTypes := v.possibleTypes(target)
+	for name := range v.possibleTypes(parent) {
+		if targetTypes[name] {
+			return true
+		}
+	}
+	v.addf(path, "fragment on %s can never apply to %s", target.Name, parent.Name)
+	return false
+}
+
+// possibleTypes returns the names of the object types a value of type def
+// may have: the type itself, a union's members or an interface's
+// implementations.
+func (v *validator) possibleTypes(def *graphql.TypeDefinition) map[string]bool {
+	names := map[string]bool{}
+	switch def.Kind {
+	case graphql.KindObject:
+		names[def.Name] = true
+	case graphql.KindUnion:
+		for _, name := range def.PossibleTypes {
+			names[name] = true
+		}
+	case graphql.KindInterface:
+		for _, t := range v.schema.Types {
+			if t.Kind == graphql.KindObject && slices.Contains(t.Interfaces, def.Name) {
+				names[t.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+func (v *validator) field(s *scope, path string, parent *graphql.TypeDefinition, field *graphql.Field) {
+	fieldPath := path + "." + field.ResponseKey()
+	v.directives(s, fieldPath, field.Directives)
+
+	switch {
+	case field.Name == "__typename":
+		return
+	case (field.Name == "__schema" || field.Name == "__type") && parent.Name == v.schema.QueryType:
+		// Introspection types are not part of the snapshot, so only note
+		// what the selection uses.
+		v.markUsed(s, field.SelectionSet)
+		return
+	case parent.Kind == graphql.KindUnion:
+		v.addf(fieldPath, "cannot select %s on union %s, use a fragment", field.Name, parent.Name)
+		return
+	}
+
+	def := parent.Field(field.Name)
+	if def == nil {
+		v.addf(fieldPath, "field %s is not defined on %s", field.Name, parent.Name)
+		return
+	}
+	v.arguments(s, fieldPath, def.Arguments, field.Arguments)
+
+	fieldType := v.schema.Type(def.Type.NamedType())
+	switch {
+	case fieldType == nil:
+		v.addf(fieldPath, "unknown type %s", def.Type.NamedType())
+	case fieldType.IsLeaf() && len(field.SelectionSet) > 0:
+		v.addf(fieldPath, "field %s of type %s cannot have a selection", field.Name, def.Type)
+	case !fieldType.IsLeaf() && len(field.SelectionSet) == 0:
+		v.addf(fieldPath, "field %s of type %s must have a selection of subfields", field.Name, def.Type)
+	case !fieldType.IsLeaf():
+		v.selectionSet(s, fieldPath, fieldType, field.SelectionSet)
+	}
+}
+
+var conditionArguments = []*graphql.InputValueDefinition{
+	{Name: "if", Type: &graphql.Type{Name: "Boolean", NonNull: true}},
+}
+
+// directives checks @include and @skip, whose definitions are built in.
+// Other directives are not in the snapshot, so only the variables they use
+// are recorded.
+func (v *validator) directives(s *scope, path string, directives []*graphql.Directive) {
+	for _, directive := range directives {
+		if directive.Name == "include" || directive.Name == "skip" {
+			v.arguments(s, path+" @"+directive.Name, conditionArguments, directive.Arguments)
+			continue
+		}
+		for _, argument := range directive.Arguments {
+			markVariables(s, argument.Value)
+		}
+	}
+}
+
+// markUsed records the fragments and variables used by selections without
+// checking them against the schema.
+func (v *validator) markUsed(s *scope, selections graphql.SelectionSet) {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *graphql.Field:
+			for _, argument := range selection.Arguments {
+				markVariables(s, argument.Value)
+			}
+			v.markUsed(s, selection.SelectionSet)
+		case *graphql.InlineFragment:
+			v.markUsed(s, selection.SelectionSet)
+		case *graphql.FragmentSpread:
+			fragment := v.doc.Fragment(selection.Name)
+			if fragment == nil || v.usedFragments[fragment.Name] {
+				continue
+			}
+			v.usedFragments[fragment.Name] = true
+			v.markUsed(s, fragment.SelectionSet)
+		}
+	}
+}
+
+func markVariables(s *scope, value *graphql.Value) {
+	switch value.Kind {
+	case graphql.ValueVariable:
+		s.usedVariables[value.Raw] = true
+	case graphql.ValueList:
+		for _, item := range value.List {
+			markVariables(s, item)
+		}
+	case graphql.ValueObject:
+		for _, field := range value.Fields {
+			markVariables(s, field.Value)
+		}
+	}
+}
+
+func (v *validator) arguments(s *scope, path string, defs []*graphql.InputValueDefinition, arguments []*graphql.Argument) {
+	supplied := map[string]bool{}
+	for _, argument := range arguments {
+		if supplied[argument.Name] {
+			v.addf(path, "argument %s is given more than once", argument.Name)
+			continue
+		}
+		supplied[argument.Name] = true
+
+		def := findInputValue(defs, argument.Name)
+		if def == nil {
+			v.addf(path, "unknown argument %s", argument.Name)
+			continue
+		}
+		v.value(s, path+"("+argument.Name+")", argument.Value, def.Type, def.DefaultValue != nil)
+	}
+
+	for _, def := range defs {
+		if def.Type.NonNull && def.DefaultValue == nil && !supplied[def.Name] {
+			v.addf(path, "missing required argument %s of type %s", def.Name, def.Type)
+		}
+	}
+}
+
+func findInputValue(defs []*graphql.InputValueDefinition, name string) *graphql.InputValueDefinition {
+	for _, def := range defs {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// value checks an input value against the type expected where it is used.
+// s is nil for variable default values, which must be constant.
+// hasDefault reports whether the position has a default, which lets a
+// nullable variable fill a non-null position.
+func (v *validator) value(s *scope, path string, value *graphql.Value, typ *graphql.Type, hasDefault bool) {
+	if value.Kind == graphql.ValueVariable {
+		v.variable(s, path, value.Raw, typ, hasDefault)
+		return
+	}
+	if value.Kind == graphql.ValueNull {
+		if typ.NonNull {
+			v.addf(path, "expected %s, found null", typ)
+		}
+		return
+	}
+
+	if typ.Elem != nil {
+		if value.Kind != graphql.ValueList {
+			// A single value is coerced to a list of one.
+			v.value(s, path, value, typ.Elem, false)
+			return
+		}
+		for _, item := range value.List {
+			v.value(s, path, item, typ.Elem, false)
+		}
+		return
+	}
+
+	def := v.schema.Type(typ.Name)
+	if def == nil {
+		v.addf(path, "unknown type %s", typ.Name)
+		return
+	}
+	switch def.Kind {
+	case graphql.KindScalar:
+		if !scalarAccepts(def.Name, value.Kind) {
+			v.addf(path, "expected %s, found %s", typ, value)
+		}
+	case graphql.KindEnum:
+		if value.Kind != graphql.ValueEnum || def.EnumValue(value.Raw) == nil {
+			v.addf(path, "expected %s, found %s", typ, value)
+		}
+	case graphql.KindInputObject:
+		if value.Kind != graphql.ValueObject {
+			v.addf(path, "expected %s, found %s", typ, value)
+			return
+		}
+		supplied := map[string]bool{}
+		for _, field := range value.Fields {
+			supplied[field.Name] = true
+			fieldDef := def.InputField(field.Name)
+			if fieldDef == nil {
+				v.addf(path, "field %s is not defined on %s", field.Name, def.Name)
+				continue
+			}
+			v.value(s, path+"."+field.Name, field.Value, fieldDef.Type, fieldDef.DefaultValue != nil)
+		}
+		for _, fieldDef := range def.InputFields {
+			if fieldDef.Type.NonNull && fieldDef.DefaultValue == nil && !supplied[fieldDef.Name] {
+				v.addf(path, "missing required field %s of type %s", fieldDef.Name, fieldDef.Type)
+			}
+		}
+	default:
+		v.addf(path, "%s is not an input type", def.Name)
+	}
+}
+
+func scalarAccepts(scalar string, kind graphql.ValueKind) bool {
+	switch scalar {
+	case "Int":
+		return kind == graphql.ValueInt
+	case "Float":
+		return kind == graphql.ValueInt || kind == graphql.ValueFloat
+	case "String":
+		return kind == graphql.ValueString
+	case "Boolean":
+		return kind == graphql.ValueBoolean
+	case "ID":
+		return kind == graphql.ValueString || kind == graphql.ValueInt
+	default:
+		// Custom scalars define their own literal formats.
+		return kind != graphql.ValueList && kind != graphql.ValueObject
+	}
+}
+
+func (v *validator) variable(s *scope, path, name string, location *graphql.Type, locationHasDefault bool) {
+	if s == nil {
+		v.addf(path, "default values cannot use variables")
+		return
+	}
+	s.usedVariables[name] = true
+
+	variable := s.op.Variable(name)
+	if variable == nil {
+		v.addf(path, "variable $%s is not defined", name)
+		return
+	}
+
+	typ := variable.Type
+	if location.NonNull && !typ.NonNull {
+		hasNonNullDefault := variable.DefaultValue != nil && variable.DefaultValue.Kind != graphql.ValueNull
+		if !hasNonNullDefault && !locationHasDefault {
+			v.addf(path, "variable $%s of type %s is used where %s is expected", name, typ, location)
+			return
+		}
+		location = nullable(location)
+	}
+	if !compatible(typ, location) {
+		v.addf(path, "variable $%s of type %s is used where %s is expected", name, typ, location)
+	}
+}
+
+// compatible reports whether a variable of type variable may be used where
+// location is expected.
+func compatible(variable, location *graphql.Type) bool {
+	if location.NonNull {
+		return variable.NonNull && compatible(nullable(variable), nullable(location))
+	}
+	if variable.NonNull {
+		return compatible(nullable(variable), location)
+	}
+	if location.Elem != nil {
+		return variable.Elem != nil && compatible(variable.Elem, location.Elem)
+	}
+	return variable.Elem == nil && variable.Name == location.Name
+}
+
+func nullable(typ *graphql.Type) *graphql.Type {
+	stripped := *typ
+	stripped.NonNull = false
+	return &stripped
+}
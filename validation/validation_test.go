@@ -0,0 +1,155 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/rockets-hq/cashramp-sdk/graphql"
+	"github.com/rockets-hq/cashramp-sdk/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+const sdl = `
+	scalar Decimal
+	enum PaymentType { deposit withdrawal }
+	input FieldInput { identifier: String! value: String }
+
+	type Query {
+		paymentRequest(reference: String!): PaymentRequest
+		payments(first: Int = 10, types: [PaymentType!]): [PaymentRequest!]!
+		node(id: ID!): Node
+	}
+	type Mutation {
+		addPaymentMethod(customer: ID!, fields: [FieldInput!]!): Boolean!
+	}
+	type PaymentRequest { id: ID! amount: Decimal! paymentType: PaymentType! customer: Customer }
+	interface Entity { id: ID! }
+	type Customer implements Entity { id: ID! email: String! }
+	union Node = PaymentRequest | Customer
+`
+
+func problems(t *testing.T, document string) []string {
+	t.Helper()
+	schema, err := graphql.ParseSchema(sdl)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	err = validation.Validate(schema, document)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*validation.Error)
+	if !assert.True(t, ok, "unexpected error %v", err) {
+		return nil
+	}
+	var messages []string
+	for _, problem := range validationErr.Problems {
+		messages = append(messages, problem.Error())
+	}
+	return messages
+}
+
+func TestValidDocuments(t *testing.T) {
+	assert.Empty(t, problems(t, `
+		query PaymentRequest($reference: String!, $withCustomer: Boolean = false) {
+			paymentRequest(reference: $reference) {
+				id
+				amount
+				customer @include(if: $withCustomer) { ...CustomerFields }
+			}
+			payments(types: [deposit]) { __typename id }
+			node(id: 1) {
+				... on Customer { email }
+				... on Entity { id }
+			}
+		}
+		mutation AddPaymentMethod($fields: [FieldInput!]!) {
+			addPaymentMethod(customer: "c1", fields: $fields)
+		}
+		fragment CustomerFields on Customer { id email }
+	`))
+	assert.Empty(t, problems(t, graphql.IntrospectionQuery))
+}
+
+func TestInvalidDocuments(t *testing.T) {
+	tests := map[string]struct {
+		document string
+		problems []string
+	}{
+		"unknown field": {
+			`{ paymentRequest(reference: "r") { id status } }`,
+			[]string{"query.paymentRequest.status: field status is not defined on PaymentRequest"},
+		},
+		"selections": {
+			`{ paymentRequest(reference: "r") { id { value } customer } }`,
+			[]string{
+				"query.paymentRequest.id: field id of type ID! cannot have a selection",
+				"query.paymentRequest.customer: field customer of type Customer must have a selection of subfields",
+			},
+		},
+		"arguments": {
+			`{ paymentRequest(ref: "r") { id } payments(first: "ten", types: [refund]) { id } }`,
+			[]string{
+				"query.paymentRequest: unknown argument ref",
+				"query.paymentRequest: missing required argument reference of type String!",
+				`query.payments(first): expected Int, found "ten"`,
+				"query.payments(types): expected PaymentType!, found refund",
+			},
+		},
+		"input objects": {
+			`mutation { addPaymentMethod(customer: "c", fields: [{value: "x", label: "y"}]) }`,
+			[]string{
+				"mutation.addPaymentMethod(fields): field label is not defined on FieldInput",
+				"mutation.addPaymentMethod(fields): missing required field identifier of type String!",
+			},
+		},
+		"variables": {
+			`query Lookup($reference: String, $unused: Int, $bad: PaymentRequest) {
+				paymentRequest(reference: $reference) { id }
+				payments(first: $missing) { id }
+			}`,
+			[]string{
+				"query Lookup($bad): variable $bad must have an input type, not PaymentRequest",
+				"query Lookup.paymentRequest(reference): variable $reference of type String is used where String! is expected",
+				"query Lookup.payments(first): variable $missing is not defined",
+				"query Lookup($unused): variable $unused is never used",
+				"query Lookup($bad): variable $bad is never used",
+			},
+		},
+		"fragments": {
+			`{ node(id: "1") { id ...Missing ... on Decimal { id } } }
+			fragment Unused on Customer { id }`,
+			[]string{
+				"query.node.id: cannot select id on union Node, use a fragment",
+				"query.node: unknown fragment Missing",
+				"query.node: fragments cannot be on scalar type Decimal",
+				"fragment Unused: fragment is never used",
+			},
+		},
+		"fragment type conditions": {
+			`{ paymentRequest(reference: "r") { id ... on Customer { email } ...CustomerFields ... on Entity { id } } }
+			fragment CustomerFields on Customer { id }`,
+			[]string{
+				"query.paymentRequest: fragment on Customer can never apply to PaymentRequest",
+				"query.paymentRequest: fragment on Customer can never apply to PaymentRequest",
+				"query.paymentRequest: fragment on Entity can never apply to PaymentRequest",
+			},
+		},
+		"operations": {
+			`subscription { payments { id } }`,
+			[]string{"subscription: schema does not support subscription operations"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.problems, problems(t, test.document))
+		})
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	schema, err := graphql.ParseSchema(sdl)
+	assert.NoError(t, err)
+	var syntaxErr *graphql.SyntaxError
+	assert.ErrorAs(t, validation.Validate(schema, "{ payments {"), &syntaxErr)
+}
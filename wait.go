@@ -0,0 +1,148 @@
+package cashrampsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/queries"
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// Default polling intervals for WaitForPaymentStatus and WaitForWithdrawal.
+const (
+	DefaultMinPollInterval = 2 * time.Second
+	DefaultMaxPollInterval = 30 * time.Second
+)
+
+// minPollInterval is the shortest interval WithPollInterval may set, so a
+// zero or tiny interval cannot hammer the API.
+const minPollInterval = 100 * time.Millisecond
+
+// ErrTerminalStatus is returned when a payment or withdrawal reaches a final
+// status the caller was not waiting for, e.g. cancelled while waiting for
+// completed.
+var ErrTerminalStatus = errors.New("reached a terminal status")
+
+// WaitOption configures WaitForPaymentStatus and WaitForWithdrawal.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	minInterval time.Duration
+	maxInterval time.Duration
+	onChange    func(types.PaymentStatus)
+}
+
+// WithPollInterval sets the bounds of the backoff between polls. Polling
+// starts at minInterval, doubles while the status is unchanged up to
+// maxInterval, and drops back to minInterval when it changes. Both are raised
+// to at least 100ms, and minInterval must not exceed maxInterval.
+func WithPollInterval(minInterval, maxInterval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.minInterval = minInterval
+		o.maxInterval = maxInterval
+	}
+}
+
+// WithStatusChange calls onChange with each status observed while waiting,
+// starting with the first one.
+func WithStatusChange(onChange func(types.PaymentStatus)) WaitOption {
+	return func(o *waitOptions) {
+		o.onChange = onChange
+	}
+}
+
+// WaitForPaymentStatus polls GetPaymentRequest until the payment request's
+// status satisfies predicate, and returns it. A nil predicate waits for any
+// terminal status. Reaching a terminal status that does not satisfy
+// predicate returns the payment request with an error wrapping
+// ErrTerminalStatus. If ctx ends first, the last payment request seen is
+// returned with ctx's error.
+func (c *Client) WaitForPaymentStatus(ctx context.Context, reference string, predicate func(types.PaymentStatus) bool, opts ...WaitOption) (*types.PaymentRequest, error) {
+	fetch := func() (*types.PaymentRequest, types.PaymentStatus, error) {
+		paymentRequest, err := SendRequestTyped[types.PaymentRequest](c, "merchantPaymentRequest", queries.PAYMENT_REQUEST, paymentRequestVariables{Reference: reference}, WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+		return &paymentRequest, paymentRequest.Status, nil
+	}
+	paymentRequest, err := pollStatus(ctx, fetch, predicate, opts)
+	if errors.Is(err, ErrTerminalStatus) {
+		return paymentRequest, fmt.Errorf("%w: payment request %s is %s", ErrTerminalStatus, reference, paymentRequest.Status)
+	}
+	return paymentRequest, err
+}
+
+// pollStatus calls fetch until the status it returns satisfies predicate or
+// is terminal, backing off between calls. Transient fetch errors are retried
+// with the same backoff until ctx ends. fetch should pass ctx to its request,
+// so a hung request ends with ctx.
+func pollStatus[T any](ctx context.Context, fetch func() (T, types.PaymentStatus, error), predicate func(types.PaymentStatus) bool, opts []WaitOption) (T, error) {
+	var last T
+	options := &waitOptions{minInterval: DefaultMinPollInterval, maxInterval: DefaultMaxPollInterval}
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.minInterval = max(options.minInterval, minPollInterval)
+	options.maxInterval = max(options.maxInterval, minPollInterval)
+	if options.minInterval > options.maxInterval {
+		return last, fmt.Errorf("poll interval minimum %s is greater than maximum %s", options.minInterval, options.maxInterval)
+	}
+	if predicate == nil {
+		predicate = types.PaymentStatus.IsTerminal
+	}
+
+	var lastStatus types.PaymentStatus
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return last, err
+		}
+		current, status, err := fetch()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return last, ctxErr
+			}
+			if !transient(err) {
+				return last, err
+			}
+			if sleepErr := sleepContext(ctx, backoffDelay(attempt, options.minInterval, options.maxInterval)); sleepErr != nil {
+				return last, fmt.Errorf("%w (last error: %v)", sleepErr, err)
+			}
+			continue
+		}
+		last = current
+
+		if status != lastStatus {
+			lastStatus = status
+			attempt = 1
+			if options.onChange != nil {
+				options.onChange(status)
+			}
+		}
+		if predicate(status) {
+			return last, nil
+		}
+		if status.IsTerminal() {
+			return last, ErrTerminalStatus
+		}
+
+		if err := sleepContext(ctx, backoffDelay(attempt, options.minInterval, options.maxInterval)); err != nil {
+			return last, err
+		}
+	}
+}
+
+// transient reports whether a failed poll may succeed if tried again: network
+// errors, rate limiting and server errors. GraphQL errors, validation errors
+// and other client errors are permanent.
+func transient(err error) bool {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.statusCode == http.StatusTooManyRequests || reqErr.statusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
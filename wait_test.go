@@ -0,0 +1,156 @@
+package cashrampsdk_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPaymentStatusServer answers the nth payment request query with the nth
+// status, repeating the last one once they run out.
+func mockPaymentStatusServer(t *testing.T, statuses ...types.PaymentStatus) (*httptest.Server, *atomic.Int32) {
+	polls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(polls.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		w.Write(createMockGraphQLResponse(t, "merchantPaymentRequest", map[string]any{
+			"id":        "pr_1",
+			"reference": "order_42",
+			"status":    status,
+		}))
+	}))
+	return server, polls
+}
+
+// fastPolling polls as often as WithPollInterval allows.
+func fastPolling() cashrampsdk.WaitOption {
+	return cashrampsdk.WithPollInterval(0, 0)
+}
+
+func TestWaitForPaymentStatus(t *testing.T) {
+	server, polls := mockPaymentStatusServer(t,
+		types.PaymentStatusCreated, types.PaymentStatusCreated, types.PaymentStatusPickedUp, types.PaymentStatusCompleted)
+	defer server.Close()
+
+	var changes []types.PaymentStatus
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42", nil,
+		fastPolling(), cashrampsdk.WithStatusChange(func(status types.PaymentStatus) {
+			changes = append(changes, status)
+		}))
+	assert.NoError(t, err)
+	assert.Equal(t, types.PaymentStatusCompleted, paymentRequest.Status)
+	assert.Equal(t, "order_42", paymentRequest.Reference)
+	assert.Equal(t, []types.PaymentStatus{types.PaymentStatusCreated, types.PaymentStatusPickedUp, types.PaymentStatusCompleted}, changes)
+	assert.Equal(t, int32(4), polls.Load())
+}
+
+func TestWaitForPaymentStatusPredicate(t *testing.T) {
+	server, polls := mockPaymentStatusServer(t, types.PaymentStatusCreated, types.PaymentStatusPickedUp, types.PaymentStatusCompleted)
+	defer server.Close()
+
+	pickedUp := func(status types.PaymentStatus) bool { return status == types.PaymentStatusPickedUp }
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42", pickedUp, fastPolling())
+	assert.NoError(t, err)
+	assert.Equal(t, types.PaymentStatusPickedUp, paymentRequest.Status)
+	assert.Equal(t, int32(2), polls.Load())
+}
+
+func TestWaitForPaymentStatusTerminal(t *testing.T) {
+	server, _ := mockPaymentStatusServer(t, types.PaymentStatusCreated, types.PaymentStatusCancelled)
+	defer server.Close()
+
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42",
+		types.PaymentStatus.IsSuccessful, fastPolling())
+	assert.ErrorIs(t, err, cashrampsdk.ErrTerminalStatus)
+	assert.ErrorContains(t, err, "order_42 is cancelled")
+	assert.Equal(t, types.PaymentStatusCancelled, paymentRequest.Status)
+}
+
+func TestWaitForPaymentStatusDeadline(t *testing.T) {
+	server, polls := mockPaymentStatusServer(t, types.PaymentStatusCreated)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(ctx, "order_42", nil, fastPolling())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	if assert.NotNil(t, paymentRequest) {
+		assert.Equal(t, types.PaymentStatusCreated, paymentRequest.Status)
+	}
+	// A zero interval is raised to the 100ms floor rather than polling flat out.
+	assert.Greater(t, polls.Load(), int32(1))
+	assert.LessOrEqual(t, polls.Load(), int32(7))
+}
+
+func TestWaitForPaymentStatusDeadlineDuringRequest(t *testing.T) {
+	polls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) > 1 {
+			// Hang until the client gives up on the request, which the
+			// server only notices once the body has been read.
+			io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			return
+		}
+		w.Write(createMockGraphQLResponse(t, "merchantPaymentRequest", map[string]any{
+			"id":        "pr_1",
+			"reference": "order_42",
+			"status":    types.PaymentStatusCreated,
+		}))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(ctx, "order_42", nil, fastPolling())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	if assert.NotNil(t, paymentRequest) {
+		assert.Equal(t, types.PaymentStatusCreated, paymentRequest.Status)
+	}
+	assert.Equal(t, int32(2), polls.Load())
+}
+
+func TestWaitForPaymentStatusRetriesTransientErrors(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(createMockGraphQLResponse(t, "merchantPaymentRequest", map[string]any{"id": "pr_1", "status": "completed"}))
+	}))
+	defer server.Close()
+
+	paymentRequest, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42", nil, fastPolling())
+	assert.NoError(t, err)
+	assert.Equal(t, types.PaymentStatusCompleted, paymentRequest.Status)
+	assert.Equal(t, int32(3), polls.Load())
+}
+
+func TestWaitForPaymentStatusInvalidInterval(t *testing.T) {
+	server, polls := mockPaymentStatusServer(t, types.PaymentStatusCreated)
+	defer server.Close()
+
+	_, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42", nil,
+		cashrampsdk.WithPollInterval(time.Minute, time.Second))
+	assert.ErrorContains(t, err, "poll interval minimum 1m0s is greater than maximum 1s")
+	assert.Equal(t, int32(0), polls.Load())
+}
+
+func TestWaitForPaymentStatusRequestError(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "merchantPaymentRequest", nil, "payment request not found"), http.StatusOK, true)
+	defer server.Close()
+
+	_, err := dummyClient(t, server).WaitForPaymentStatus(context.Background(), "order_42", nil, fastPolling())
+	assert.ErrorContains(t, err, "payment request not found")
+}
@@ -0,0 +1,249 @@
+// Package webhooks receives Cashramp webhook notifications. Each request is
+// signed with the merchant's webhook secret in the Cashramp-Signature header:
+//
+//	Cashramp-Signature: t=1767225600,v1=<signature>
+//
+// where t is the Unix time the request was signed and the signature is the
+// hex HMAC-SHA256 of "<t>.<body>". Several v1 values may be present while a
+// secret is being rotated; any one matching is enough.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// SignatureHeader is the header requests are signed in.
+const SignatureHeader = "Cashramp-Signature"
+
+// DefaultTolerance is how far a signature's timestamp may be from the current
+// time before the request is rejected as a possible replay.
+const DefaultTolerance = 5 * time.Minute
+
+// MaxBodySize is the largest request body Handler reads.
+const MaxBodySize = 1 << 20
+
+var (
+	ErrMissingSecret    = errors.New("webhooks: missing webhook secret")
+	ErrMissingSignature = errors.New("webhooks: missing signature")
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+	ErrTimestampExpired = errors.New("webhooks: timestamp outside tolerance")
+)
+
+// EventType names what happened.
+type EventType string
+
+const (
+	// EventPaymentRequestUpdated is sent when a payment request changes
+	// status. Event.PaymentRequest is set.
+	EventPaymentRequestUpdated EventType = "payment_request.updated"
+	// EventWithdrawalUpdated is sent when an onchain withdrawal changes
+	// status. Event.Withdrawal is set.
+	EventWithdrawalUpdated EventType = "withdrawal.updated"
+)
+
+// Event is a webhook notification. Events of types this package does not
+// know about are still delivered, with only Data set.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+
+	PaymentRequest *types.PaymentRequest `json:"-"`
+	Withdrawal     *types.Withdrawal     `json:"-"`
+}
+
+// Status returns the status of the payment request or withdrawal the event
+// is about, or "" for other events.
+func (e Event) Status() types.PaymentStatus {
+	switch {
+	case e.PaymentRequest != nil:
+		return e.PaymentRequest.Status
+	case e.Withdrawal != nil:
+		return e.Withdrawal.Status
+	}
+	return ""
+}
+
+// ParseEvent decodes a webhook body. It does not check the signature; use
+// VerifySignature first, or Handler.
+func ParseEvent(payload []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return event, fmt.Errorf("webhooks: decoding event: %w", err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return event, errors.New("webhooks: event is missing its id or type")
+	}
+
+	var target any
+	switch event.Type {
+	case EventPaymentRequestUpdated:
+		event.PaymentRequest = &types.PaymentRequest{}
+		target = event.PaymentRequest
+	case EventWithdrawalUpdated:
+		event.Withdrawal = &types.Withdrawal{}
+		target = event.Withdrawal
+	default:
+		return event, nil
+	}
+	if err := json.Unmarshal(event.Data, target); err != nil {
+		return event, fmt.Errorf("webhooks: decoding %s data: %w", event.Type, err)
+	}
+	return event, nil
+}
+
+// Sign returns the Cashramp-Signature header value for payload signed at t,
+// for testing handlers.
+func Sign(payload []byte, secret string, t time.Time) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(signature(payload, secret, timestamp)))
+}
+
+func signature(payload []byte, secret, timestamp string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// VerifySignature checks header, the Cashramp-Signature value, against
+// payload and secret, and that it was signed within tolerance of now. An
+// empty secret is rejected with ErrMissingSecret rather than verified against.
+func VerifySignature(payload []byte, header, secret string, now time.Time, tolerance time.Duration) error {
+	if secret == "" {
+		return ErrMissingSecret
+	}
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	var timestamp string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			if decoded, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, decoded)
+			}
+		}
+	}
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed %s header", ErrInvalidSignature, SignatureHeader)
+	}
+
+	expected := signature(payload, secret, timestamp)
+	valid := false
+	for _, candidate := range signatures {
+		// Check every candidate so timing does not reveal which one matched.
+		if hmac.Equal(candidate, expected) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(signedAt, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: signed %s ago", ErrTimestampExpired, age.Round(time.Second))
+	}
+	return nil
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTolerance sets how old, or how far in the future, a signature's
+// timestamp may be. A tolerance that is not positive would reject every
+// request, so DefaultTolerance is kept instead.
+func WithTolerance(tolerance time.Duration) Option {
+	return func(h *Handler) {
+		if tolerance > 0 {
+			h.tolerance = tolerance
+		}
+	}
+}
+
+// Handler is an http.Handler that verifies webhook requests and passes their
+// events to a function. It responds with:
+//
+//   - 200 once the function returns nil
+//   - 400 if the body is not a valid event
+//   - 401 if the signature is missing, wrong or outside the tolerance
+//   - 405 for methods other than POST
+//   - 413 if the body is larger than MaxBodySize
+//   - 500 if the function returns an error, so the event is redelivered
+type Handler struct {
+	secret    string
+	handle    func(context.Context, Event) error
+	tolerance time.Duration
+}
+
+// NewHandler returns a Handler that calls handle with each verified event.
+// handle should be idempotent, since events may be delivered more than once.
+// It returns ErrMissingSecret if secret is empty, which would otherwise
+// accept forged events when the secret is missing from the environment.
+func NewHandler(secret string, handle func(context.Context, Event) error, opts ...Option) (*Handler, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	h := &Handler{secret: secret, handle: handle, tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "cannot read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := VerifySignature(payload, r.Header.Get(SignatureHeader), h.secret, time.Now(), h.tolerance); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseEvent(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.handle(r.Context(), event); err != nil {
+		http.Error(w, "event not processed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,158 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/rockets-hq/cashramp-sdk/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "whsec_test"
+
+const paymentEvent = `{
+	"id": "evt_1",
+	"type": "payment_request.updated",
+	"createdAt": "2026-03-01T09:00:00Z",
+	"data": {"id": "pr_1", "reference": "order_42", "amount": 25, "currency": "USD", "status": "completed"}
+}`
+
+func deliver(handler http.Handler, method, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/webhooks/cashramp", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(webhooks.SignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	var received []webhooks.Event
+	handler, err := webhooks.NewHandler(secret, func(ctx context.Context, event webhooks.Event) error {
+		received = append(received, event)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	rec := deliver(handler, http.MethodPost, paymentEvent, webhooks.Sign([]byte(paymentEvent), secret, time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, received, 1) {
+		event := received[0]
+		assert.Equal(t, "evt_1", event.ID)
+		assert.Equal(t, webhooks.EventPaymentRequestUpdated, event.Type)
+		assert.Equal(t, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), event.CreatedAt)
+		assert.Equal(t, "order_42", event.PaymentRequest.Reference)
+		assert.Equal(t, types.CurrencyCode("USD"), event.PaymentRequest.Currency)
+		assert.Equal(t, types.PaymentStatusCompleted, event.Status())
+		assert.Nil(t, event.Withdrawal)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	called := false
+	handler, err := webhooks.NewHandler(secret, func(ctx context.Context, event webhooks.Event) error {
+		called = true
+		return nil
+	}, webhooks.WithTolerance(time.Minute))
+	assert.NoError(t, err)
+	now := time.Now()
+	sign := func(body string, t time.Time) string { return webhooks.Sign([]byte(body), secret, t) }
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		signature string
+		status    int
+	}{
+		{"wrong method", http.MethodGet, paymentEvent, sign(paymentEvent, now), http.StatusMethodNotAllowed},
+		{"missing signature", http.MethodPost, paymentEvent, "", http.StatusUnauthorized},
+		{"malformed signature", http.MethodPost, paymentEvent, "v1=zz", http.StatusUnauthorized},
+		{"wrong secret", http.MethodPost, paymentEvent, webhooks.Sign([]byte(paymentEvent), "whsec_other", now), http.StatusUnauthorized},
+		{"tampered body", http.MethodPost, strings.Replace(paymentEvent, "25", "2500", 1), sign(paymentEvent, now), http.StatusUnauthorized},
+		{"replayed", http.MethodPost, paymentEvent, sign(paymentEvent, now.Add(-2*time.Minute)), http.StatusUnauthorized},
+		{"from the future", http.MethodPost, paymentEvent, sign(paymentEvent, now.Add(2*time.Minute)), http.StatusUnauthorized},
+		{"invalid json", http.MethodPost, `{"id":`, sign(`{"id":`, now), http.StatusBadRequest},
+		{"missing type", http.MethodPost, `{"id":"evt_1"}`, sign(`{"id":"evt_1"}`, now), http.StatusBadRequest},
+		{"too large", http.MethodPost, strings.Repeat(" ", webhooks.MaxBodySize+1), "t=1,v1=00", http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := deliver(handler, test.method, test.body, test.signature)
+			assert.Equal(t, test.status, rec.Code)
+			assert.False(t, called)
+		})
+	}
+	assert.Equal(t, http.MethodPost, deliver(handler, http.MethodGet, "", "").Header().Get("Allow"))
+}
+
+func TestHandlerError(t *testing.T) {
+	handler, err := webhooks.NewHandler(secret, func(ctx context.Context, event webhooks.Event) error {
+		return errors.New("database unavailable")
+	})
+	assert.NoError(t, err)
+
+	rec := deliver(handler, http.MethodPost, paymentEvent, webhooks.Sign([]byte(paymentEvent), secret, time.Now()))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "database")
+}
+
+func TestNewHandlerMissingSecret(t *testing.T) {
+	handler, err := webhooks.NewHandler("", func(ctx context.Context, event webhooks.Event) error { return nil })
+	assert.ErrorIs(t, err, webhooks.ErrMissingSecret)
+	assert.Nil(t, handler)
+}
+
+func TestHandlerNonPositiveTolerance(t *testing.T) {
+	for _, tolerance := range []time.Duration{0, -time.Minute} {
+		handler, err := webhooks.NewHandler(secret, func(ctx context.Context, event webhooks.Event) error { return nil },
+			webhooks.WithTolerance(tolerance))
+		assert.NoError(t, err)
+
+		// DefaultTolerance applies, rather than every request being rejected.
+		now := time.Now()
+		rec := deliver(handler, http.MethodPost, paymentEvent, webhooks.Sign([]byte(paymentEvent), secret, now.Add(-time.Minute)))
+		assert.Equal(t, http.StatusOK, rec.Code, "tolerance %s", tolerance)
+		rec = deliver(handler, http.MethodPost, paymentEvent, webhooks.Sign([]byte(paymentEvent), secret, now.Add(-2*webhooks.DefaultTolerance)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "tolerance %s", tolerance)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(paymentEvent)
+	now := time.Unix(1767225600, 0)
+
+	assert.NoError(t, webhooks.VerifySignature(payload, webhooks.Sign(payload, secret, now), secret, now, time.Minute))
+
+	// During secret rotation either signature is accepted.
+	rotating := webhooks.Sign(payload, "whsec_old", now) + "," + strings.Split(webhooks.Sign(payload, secret, now), ",")[1]
+	assert.NoError(t, webhooks.VerifySignature(payload, rotating, secret, now, time.Minute))
+
+	assert.ErrorIs(t, webhooks.VerifySignature(payload, "", secret, now, time.Minute), webhooks.ErrMissingSignature)
+	assert.ErrorIs(t, webhooks.VerifySignature(payload, webhooks.Sign(payload, "", now), "", now, time.Minute), webhooks.ErrMissingSecret)
+	assert.ErrorIs(t, webhooks.VerifySignature(payload, "t=abc,v1=00", secret, now, time.Minute), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.VerifySignature(payload, webhooks.Sign(payload, secret, now), secret, now.Add(time.Hour), time.Minute), webhooks.ErrTimestampExpired)
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := webhooks.ParseEvent([]byte(`{"id":"evt_2","type":"withdrawal.updated","data":{"id":"wd_1","status":"failed","network":"TRC20"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "TRC20", event.Withdrawal.Network)
+	assert.Equal(t, types.PaymentStatusFailed, event.Status())
+
+	event, err = webhooks.ParseEvent([]byte(`{"id":"evt_3","type":"customer.created","data":{"id":"cus_1"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, webhooks.EventType("customer.created"), event.Type)
+	assert.JSONEq(t, `{"id":"cus_1"}`, string(event.Data))
+	assert.Equal(t, types.PaymentStatus(""), event.Status())
+
+	_, err = webhooks.ParseEvent([]byte(`{"id":"evt_4","type":"payment_request.updated","data":{"amount":"lots"}}`))
+	assert.ErrorContains(t, err, "decoding payment_request.updated data")
+}
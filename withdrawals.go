@@ -0,0 +1,56 @@
+package cashrampsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rockets-hq/cashramp-sdk/types"
+)
+
+// ErrWithdrawalNotFound is returned when no onchain withdrawal has the given
+// ID.
+var ErrWithdrawalNotFound = errors.New("withdrawal not found")
+
+// GetWithdrawal fetches an onchain withdrawal started with WithdrawOnchain.
+// The transaction hash and network are set once the withdrawal has been
+// broadcast. It returns an error wrapping ErrWithdrawalNotFound if there is
+// no such withdrawal.
+func (c *Client) GetWithdrawal(withdrawalID string) (*types.Withdrawal, error) {
+	return c.getWithdrawal(withdrawalID)
+}
+
+func (c *Client) getWithdrawal(withdrawalID string, opts ...RequestOption) (*types.Withdrawal, error) {
+	if err := requireField("id", withdrawalID); err != nil {
+		return nil, err
+	}
+
+	withdrawal, err := c.doOnchainWithdrawal(withdrawalVariables{ID: withdrawalID}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if withdrawal.ID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrWithdrawalNotFound, withdrawalID)
+	}
+	return withdrawal, nil
+}
+
+// WaitForWithdrawal polls GetWithdrawal until the withdrawal is completed,
+// and returns it. A withdrawal that fails or is cancelled is returned with an
+// error wrapping ErrTerminalStatus. If ctx ends first, the last withdrawal
+// seen is returned with ctx's error. Polling is configured as for
+// WaitForPaymentStatus.
+func (c *Client) WaitForWithdrawal(ctx context.Context, withdrawalID string, opts ...WaitOption) (*types.Withdrawal, error) {
+	fetch := func() (*types.Withdrawal, types.PaymentStatus, error) {
+		withdrawal, err := c.getWithdrawal(withdrawalID, WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+		return withdrawal, withdrawal.Status, nil
+	}
+	withdrawal, err := pollStatus(ctx, fetch, types.PaymentStatus.IsSuccessful, opts)
+	if errors.Is(err, ErrTerminalStatus) {
+		return withdrawal, fmt.Errorf("%w: withdrawal %s is %s", ErrTerminalStatus, withdrawalID, withdrawal.Status)
+	}
+	return withdrawal, err
+}
@@ -0,0 +1,98 @@
+package cashrampsdk_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cashrampsdk "github.com/rockets-hq/cashramp-sdk"
+	"github.com/rockets-hq/cashramp-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWithdrawal(t *testing.T) {
+	server := mockGraphQLServer(t, createMockGraphQLResponse(t, "onchainWithdrawal", map[string]any{
+		"id":              "wd_1",
+		"status":          "completed",
+		"address":         "TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE",
+		"network":         "TRC20",
+		"transactionHash": "0xabc",
+		"amountUsd":       "25.10",
+		"feeUsd":          1.35,
+	}), http.StatusOK, true, `"operationName":"Withdrawal"`, `"id":"wd_1"`)
+	defer server.Close()
+
+	withdrawal, err := dummyClient(t, server).GetWithdrawal("wd_1")
+	assert.NoError(t, err)
+	assert.Equal(t, types.PaymentStatusCompleted, withdrawal.Status)
+	assert.Equal(t, "TRC20", withdrawal.Network)
+	assert.Equal(t, "0xabc", withdrawal.TransactionHash)
+	assert.Equal(t, "25.1", withdrawal.AmountUsd.String())
+	assert.Equal(t, "1.35", withdrawal.FeeUsd.String())
+}
+
+func TestGetWithdrawalNotFound(t *testing.T) {
+	server := mockGraphQLServer(t, []byte(`{"data":{"onchainWithdrawal":null}}`), http.StatusOK, true)
+	defer server.Close()
+
+	_, err := dummyClient(t, server).GetWithdrawal("wd_404")
+	assert.ErrorIs(t, err, cashrampsdk.ErrWithdrawalNotFound)
+}
+
+// mockWithdrawalServer answers the nth withdrawal query with the nth status,
+// repeating the last one once they run out.
+func mockWithdrawalServer(t *testing.T, statuses ...types.PaymentStatus) *httptest.Server {
+	polls := &atomic.Int32{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(polls.Add(1))
+		withdrawal := map[string]any{"id": "wd_1", "status": statuses[min(n, len(statuses))-1]}
+		if withdrawal["status"] == types.PaymentStatusCompleted {
+			withdrawal["transactionHash"] = "0xabc"
+		}
+		w.Write(createMockGraphQLResponse(t, "onchainWithdrawal", withdrawal))
+	}))
+}
+
+func TestWaitForWithdrawal(t *testing.T) {
+	server := mockWithdrawalServer(t, types.PaymentStatusCreated, types.PaymentStatusPickedUp, types.PaymentStatusCompleted)
+	defer server.Close()
+
+	var changes []types.PaymentStatus
+	withdrawal, err := dummyClient(t, server).WaitForWithdrawal(context.Background(), "wd_1",
+		fastPolling(), cashrampsdk.WithStatusChange(func(status types.PaymentStatus) {
+			changes = append(changes, status)
+		}))
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabc", withdrawal.TransactionHash)
+	assert.Equal(t, []types.PaymentStatus{types.PaymentStatusCreated, types.PaymentStatusPickedUp, types.PaymentStatusCompleted}, changes)
+}
+
+func TestWaitForWithdrawalFailed(t *testing.T) {
+	server := mockWithdrawalServer(t, types.PaymentStatusCreated, types.PaymentStatusFailed)
+	defer server.Close()
+
+	withdrawal, err := dummyClient(t, server).WaitForWithdrawal(context.Background(), "wd_1", fastPolling())
+	assert.ErrorIs(t, err, cashrampsdk.ErrTerminalStatus)
+	assert.ErrorContains(t, err, "withdrawal wd_1 is failed")
+	assert.Equal(t, types.PaymentStatusFailed, withdrawal.Status)
+}
+
+func TestWaitForWithdrawalDeadlineDuringRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	withdrawal, err := dummyClient(t, server).WaitForWithdrawal(ctx, "wd_1", fastPolling())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Nil(t, withdrawal)
+}